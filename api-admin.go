@@ -0,0 +1,65 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// minioAdminPrefix - path prefix of the MinIO admin API.
+const minioAdminPrefix = "/minio/admin/v3/"
+
+// adminGetJSON - executes GET on the MinIO admin API path and decodes
+// the JSON response into v. bucketName is only used for errors.
+func (c Client) adminGetJSON(path string, urlValues url.Values, bucketName string, v interface{}) error {
+	resp, err := c.executeMethod("GET", requestMetadata{
+		adminPath:          minioAdminPrefix + path,
+		queryValues:        urlValues,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return adminRespToErrorResponse(resp, bucketName)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// adminRespToErrorResponse - returns the JSON error of an admin API
+// response, falling back to the S3 error handling otherwise.
+func adminRespToErrorResponse(resp *http.Response, bucketName string) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) == nil && errResp.Code != "" {
+		errResp.BucketName = bucketName
+		errResp.RequestID = resp.Header.Get("x-amz-request-id")
+		errResp.Headers = resp.Header
+		return errResp
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return httpRespToErrorResponse(resp, bucketName, "")
+}
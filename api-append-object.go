@@ -0,0 +1,190 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// AppendObject - appends data read from reader to the end of an
+// existing object, emulating append semantics for log style workloads.
+// If the object does not exist yet it is created with the data.
+//
+// The existing object is composed server side with the new data as a
+// multipart upload, where the object content is copied into leading
+// parts and the new data is uploaded as the last part. No content of
+// the existing object is transferred through the client, except for
+// objects smaller than 5MiB which cannot be copied as a part and are
+// re-uploaded along with the new data instead.
+//
+// Content type and user metadata of the existing object are preserved.
+// Maximum data that can be appended in a single call is 5GiB.
+func (c Client) AppendObject(bucketName, objectName string, reader io.Reader) (n int64, err error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return 0, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return 0, err
+	}
+	if reader == nil {
+		return 0, ErrInvalidArgument("Input reader is invalid, cannot be nil.")
+	}
+	if c.dryRun("append to object %s/%s", bucketName, objectName) {
+		return 0, nil
+	}
+
+	objInfo, err := c.StatObject(bucketName, objectName)
+	if err != nil {
+		if ToErrorResponse(err).Code != "NoSuchKey" {
+			return 0, err
+		}
+		// Object does not exist, nothing to append to.
+		return c.PutObjectWithMetadata(bucketName, objectName, reader, nil, nil)
+	}
+
+	// Add the appropriate hash algorithms that need to be calculated by hashCopyN
+	// In case of non-v4 signature request or HTTPS connection, sha256 is not needed.
+	hashAlgos, hashSums := c.hashMaterials()
+
+	// Stage the data to be appended in a temporary file, it is
+	// uploaded as a single part.
+	tmpFile, err := newTempFile("append$-putobject-part")
+	if err != nil {
+		return 0, err
+	}
+	defer tmpFile.Close()
+
+	size, err := hashCopyN(hashAlgos, hashSums, tmpFile, reader, maxPartSize)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if err == nil {
+		// Read exactly maxPartSize, verify if there is more to read.
+		if rn, _ := reader.Read(make([]byte, 1)); rn > 0 {
+			return 0, ErrEntityTooLarge(size+int64(rn), maxPartSize, bucketName, objectName)
+		}
+	}
+	if size == 0 {
+		// Nothing to append.
+		return 0, nil
+	}
+
+	// Seek back to beginning of the temporary file.
+	if _, err = tmpFile.Seek(0, 0); err != nil {
+		return 0, err
+	}
+
+	// Preserve the content type and user metadata of the existing object.
+	metaData := appendObjectMetadata(objInfo)
+
+	// Existing objects smaller than the minimum part size cannot be
+	// copied as a part, upload them along with the appended data.
+	if objInfo.Size < absMinPartSize {
+		reqHeaders := NewGetReqHeaders()
+		if err = reqHeaders.SetMatchETag(objInfo.ETag); err != nil {
+			return 0, err
+		}
+		objReader, _, err := c.getObject(bucketName, objectName, reqHeaders)
+		if err != nil {
+			return 0, err
+		}
+		defer objReader.Close()
+		if _, err = c.putObjectSingle(bucketName, objectName, io.MultiReader(objReader, tmpFile), objInfo.Size+size, metaData, nil); err != nil {
+			return 0, err
+		}
+		return size, nil
+	}
+
+	if err = c.appendObjectMultipart(bucketName, objectName, objInfo, tmpFile, size, hashSums, metaData); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// appendObjectMultipart - composes the existing object and the staged
+// data using a multipart upload of copied parts and a new last part.
+func (c Client) appendObjectMultipart(bucketName, objectName string, objInfo ObjectInfo, reader io.Reader, size int64, hashSums map[string][]byte, metaData map[string][]string) (err error) {
+	// Calculate the parts info for the existing object size.
+	totalPartsCount, _, _, err := copyPartInfo(objInfo.Size)
+	if err != nil {
+		return err
+	}
+	if totalPartsCount >= maxPartsCount {
+		return ErrEntityTooLarge(objInfo.Size+size, maxMultipartPutObjectSize, bucketName, objectName)
+	}
+
+	// Initiate a new multipart upload.
+	uploadID, err := c.newUploadID(bucketName, objectName, metaData)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Abort the multipart upload upon any failure.
+		if err != nil {
+			c.abortMultipartUpload(bucketName, objectName, uploadID)
+		}
+	}()
+
+	// Copy only if the existing object has not changed since it was stat'ed.
+	cpCond := CopyConditions{}
+	if err = cpCond.SetMatchETag(objInfo.ETag); err != nil {
+		return err
+	}
+
+	// Complete multipart upload.
+	var complMultipartUpload completeMultipartUpload
+
+	// Copy the existing object into the leading parts.
+	complMultipartUpload.Parts, err = c.copyObjectParts(bucketName, objectName, uploadID, bucketName+"/"+objectName, objInfo.Size, cpCond)
+	if err != nil {
+		return err
+	}
+
+	// Upload the data to be appended as the last part.
+	objPart, err := c.uploadPart(bucketName, objectName, uploadID, reader, totalPartsCount+1, hashSums["md5"], hashSums["sha256"], size)
+	if err != nil {
+		return err
+	}
+	complMultipartUpload.Parts = append(complMultipartUpload.Parts, CompletePart{
+		ETag:       objPart.ETag,
+		PartNumber: objPart.PartNumber,
+	})
+
+	// Sort all completed parts.
+	sort.Sort(completedParts(complMultipartUpload.Parts))
+	_, err = c.completeMultipartUpload(bucketName, objectName, uploadID, complMultipartUpload)
+	return err
+}
+
+// appendObjectMetadata - returns the content type and user metadata of
+// an object, to be set again on the composed object.
+func appendObjectMetadata(objInfo ObjectInfo) map[string][]string {
+	metaData := make(map[string][]string)
+	metaData["Content-Type"] = []string{objInfo.ContentType}
+	for k, v := range objInfo.Metadata {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			metaData[k] = v
+		}
+	}
+	return metaData
+}
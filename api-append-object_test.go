@@ -0,0 +1,109 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Tests appending to large objects composes them from copied parts
+// no smaller than the minimum part size, followed by the new data.
+func TestAppendObjectCompose(t *testing.T) {
+	existingSize := int64(minPartSize + 1024*1024)
+	var mutex sync.Mutex
+	partSizes := make(map[int]int64)
+	var completed completeMultipartUpload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		_, location := query["location"]
+		_, uploads := query["uploads"]
+		switch {
+		case location:
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+		case r.Method == "HEAD":
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Content-Length", strconv.FormatInt(existingSize, 10))
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		case r.Method == "POST" && uploads:
+			fmt.Fprint(w, `<InitiateMultipartUploadResult><UploadId>upload</UploadId></InitiateMultipartUploadResult>`)
+		case r.Method == "PUT" && query.Get("partNumber") != "":
+			partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+			var size int64
+			if source := r.Header.Get("x-amz-copy-source-range"); source != "" {
+				var first, last int64
+				fmt.Sscanf(source, "bytes=%d-%d", &first, &last)
+				size = last - first + 1
+				fmt.Fprintf(w, `<CopyPartResult><ETag>"etag-%d"</ETag></CopyPartResult>`, partNumber)
+			} else {
+				data, _ := ioutil.ReadAll(r.Body)
+				size = int64(len(data))
+				w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, partNumber))
+			}
+			mutex.Lock()
+			partSizes[partNumber] = size
+			mutex.Unlock()
+		case r.Method == "POST" && query.Get("uploadId") != "":
+			data, _ := ioutil.ReadAll(r.Body)
+			xml.Unmarshal(data, &completed)
+			fmt.Fprint(w, `<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><ETag>"etag"</ETag></CompleteMultipartUploadResult>`)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewWithRegion(server.Listener.Addr().String(), "access", "secret", false, "us-east-1")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	n, err := clnt.AppendObject("bucket", "object", strings.NewReader("appended"))
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if n != 8 {
+		t.Fatalf("Expected 8 bytes appended, got %d", n)
+	}
+
+	// The 1MiB remainder is copied along with the first part.
+	if len(completed.Parts) != 2 {
+		t.Fatalf("Expected 2 completed parts, got %v", completed.Parts)
+	}
+	var total int64
+	for i, part := range completed.Parts {
+		if part.PartNumber != i+1 {
+			t.Fatalf("Unexpected part %d: %v", i+1, part)
+		}
+		size := partSizes[part.PartNumber]
+		if i < len(completed.Parts)-1 && size < absMinPartSize {
+			t.Errorf("Part %d of %d bytes is smaller than the minimum part size", part.PartNumber, size)
+		}
+		total += size
+	}
+	if total != existingSize+8 {
+		t.Errorf("Expected %d bytes composed, got %d", existingSize+8, total)
+	}
+}
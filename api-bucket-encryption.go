@@ -0,0 +1,139 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// SetBucketEncryption - sets the default encryption configuration of
+// a bucket, applied to objects created without encryption headers.
+func (c Client) SetBucketEncryption(bucketName string, config ServerSideEncryptionConfiguration) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	// Get resources properly escaped and lined up before
+	// using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set("encryption", "")
+
+	configBytes, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if c.dryRun("set bucket encryption on %s: %s", bucketName, configBytes) {
+		return nil
+	}
+
+	reqMetadata := requestMetadata{
+		bucketName:         bucketName,
+		queryValues:        urlValues,
+		contentBody:        bytes.NewReader(configBytes),
+		contentLength:      int64(len(configBytes)),
+		contentMD5Bytes:    sumMD5(configBytes),
+		contentSHA256Bytes: sum256(configBytes),
+	}
+
+	// Execute PUT to set the bucket encryption configuration.
+	resp, err := c.executeMethod("PUT", reqMetadata)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return httpRespToErrorResponse(resp, bucketName, "")
+		}
+	}
+	return nil
+}
+
+// GetBucketEncryption - gets the default encryption configuration of
+// a bucket. Returns ServerSideEncryptionConfigurationNotFoundError if
+// no configuration is set.
+func (c Client) GetBucketEncryption(bucketName string) (ServerSideEncryptionConfiguration, error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return ServerSideEncryptionConfiguration{}, err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("encryption", "")
+
+	// Execute GET on bucket to get the encryption configuration.
+	resp, err := c.executeMethod("GET", requestMetadata{
+		bucketName:         bucketName,
+		queryValues:        urlValues,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return ServerSideEncryptionConfiguration{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return ServerSideEncryptionConfiguration{}, httpRespToErrorResponse(resp, bucketName, "")
+	}
+
+	var config ServerSideEncryptionConfiguration
+	if err = xmlDecoder(resp.Body, &config); err != nil {
+		return ServerSideEncryptionConfiguration{}, err
+	}
+	return config, nil
+}
+
+// DeleteBucketEncryption - removes the default encryption
+// configuration of a bucket.
+func (c Client) DeleteBucketEncryption(bucketName string) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if c.dryRun("delete bucket encryption on %s", bucketName) {
+		return nil
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("encryption", "")
+
+	// Execute DELETE on bucket to remove the encryption configuration.
+	resp, err := c.executeMethod("DELETE", requestMetadata{
+		bucketName:         bucketName,
+		queryValues:        urlValues,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			return httpRespToErrorResponse(resp, bucketName, "")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// BucketEndpoint - alternate endpoint a bucket is reached through, such
+// as a CDN or a custom domain bound to the bucket.
+type BucketEndpoint struct {
+	// URL serving the bucket at its path, objects are requested at
+	// the URL path followed by their name.
+	URL string
+
+	// ReadOnly sends only GET and HEAD requests to URL, all other
+	// requests go to the endpoint of the client.
+	ReadOnly bool
+
+	// SignCanonicalHost signs requests as if they were sent to the
+	// endpoint of the client, for proxies which forward requests to
+	// the origin as they are. Otherwise requests are signed for URL.
+	SignCanonicalHost bool
+}
+
+// bucketEndpoint - parsed alternate endpoint of a bucket.
+type bucketEndpoint struct {
+	BucketEndpoint
+	url *url.URL
+}
+
+// bucketEndpoints - alternate endpoints of buckets, safe for
+// concurrent use.
+type bucketEndpoints struct {
+	sync.RWMutex
+	items map[string]bucketEndpoint
+}
+
+// newBucketEndpoints - returns an empty set of alternate endpoints.
+func newBucketEndpoints() *bucketEndpoints {
+	return &bucketEndpoints{
+		items: make(map[string]bucketEndpoint),
+	}
+}
+
+// SetBucketEndpoint - sends requests for bucketName to an alternate
+// endpoint, for instance reads through a CDN while writes still go to
+// the origin.
+func (c *Client) SetBucketEndpoint(bucketName string, endpoint BucketEndpoint) error {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	u, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return ErrInvalidArgument("Bucket endpoint URL is invalid: " + err.Error())
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return ErrInvalidArgument("Bucket endpoint URL should be an absolute http or https URL.")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return ErrInvalidArgument("Bucket endpoint URL cannot have a query or fragment.")
+	}
+	c.bucketEndpoints.Lock()
+	defer c.bucketEndpoints.Unlock()
+	c.bucketEndpoints.items[bucketName] = bucketEndpoint{endpoint, u}
+	return nil
+}
+
+// RemoveBucketEndpoint - sends requests for bucketName to the endpoint
+// of the client again.
+func (c *Client) RemoveBucketEndpoint(bucketName string) {
+	c.bucketEndpoints.Lock()
+	defer c.bucketEndpoints.Unlock()
+	delete(c.bucketEndpoints.items, bucketName)
+}
+
+// bucketEndpointURL - returns the URL of the alternate endpoint a
+// request is sent to, nil if it is sent to targetURL, and whether it
+// is signed for targetURL.
+func (c Client) bucketEndpointURL(method string, metadata requestMetadata, targetURL *url.URL) (*url.URL, bool) {
+	if metadata.bucketName == "" || metadata.adminPath != "" {
+		return nil, false
+	}
+	c.bucketEndpoints.RLock()
+	endpoint, ok := c.bucketEndpoints.items[metadata.bucketName]
+	c.bucketEndpoints.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if endpoint.ReadOnly && method != "GET" && method != "HEAD" {
+		return nil, false
+	}
+
+	// Object names are encoded as for the endpoint of the client.
+	urlStr := endpoint.url.Scheme + "://" + endpoint.url.Host + strings.TrimSuffix(endpoint.url.EscapedPath(), "/") + "/"
+	if metadata.objectName != "" {
+		urlStr = urlStr + s3utils.EncodePath(metadata.objectName)
+	}
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, false
+	}
+	u.RawQuery = targetURL.RawQuery
+	return u, endpoint.SignCanonicalHost
+}
+
+// redirectRequest - sends a signed request to u instead, keeping the
+// query which may carry the signature.
+func redirectRequest(req *http.Request, u *url.URL) {
+	redirected := *req.URL
+	redirected.Scheme = u.Scheme
+	redirected.Host = u.Host
+	redirected.Path = u.Path
+	redirected.RawPath = u.RawPath
+	req.URL = &redirected
+	req.Host = u.Host
+}
@@ -0,0 +1,119 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// SetBucketLogging - sets the access logging configuration of a
+// bucket, a zero BucketLogging disables access logging.
+func (c Client) SetBucketLogging(bucketName string, logging BucketLogging) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := logging.validate(); err != nil {
+		return err
+	}
+
+	// Get resources properly escaped and lined up before
+	// using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set("logging", "")
+
+	status := bucketLoggingStatus{}
+	if logging.Enabled() {
+		status.LoggingEnabled = &loggingEnabled{
+			TargetBucket: logging.TargetBucket,
+			TargetPrefix: logging.TargetPrefix,
+		}
+	}
+	statusBytes, err := xml.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if c.dryRun("set bucket logging on %s: %s", bucketName, statusBytes) {
+		return nil
+	}
+
+	reqMetadata := requestMetadata{
+		bucketName:         bucketName,
+		queryValues:        urlValues,
+		contentBody:        bytes.NewReader(statusBytes),
+		contentLength:      int64(len(statusBytes)),
+		contentMD5Bytes:    sumMD5(statusBytes),
+		contentSHA256Bytes: sum256(statusBytes),
+	}
+
+	// Execute PUT to set the bucket logging configuration.
+	resp, err := c.executeMethod("PUT", reqMetadata)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return httpRespToErrorResponse(resp, bucketName, "")
+		}
+	}
+	return nil
+}
+
+// GetBucketLogging - gets the access logging configuration of a
+// bucket, a zero BucketLogging is returned if logging is disabled.
+func (c Client) GetBucketLogging(bucketName string) (BucketLogging, error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return BucketLogging{}, err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("logging", "")
+
+	// Execute GET on bucket to get the logging configuration.
+	resp, err := c.executeMethod("GET", requestMetadata{
+		bucketName:         bucketName,
+		queryValues:        urlValues,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return BucketLogging{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return BucketLogging{}, httpRespToErrorResponse(resp, bucketName, "")
+	}
+
+	status := bucketLoggingStatus{}
+	if err = xmlDecoder(resp.Body, &status); err != nil {
+		return BucketLogging{}, err
+	}
+	if status.LoggingEnabled == nil {
+		return BucketLogging{}, nil
+	}
+	return BucketLogging{
+		TargetBucket: status.LoggingEnabled.TargetBucket,
+		TargetPrefix: status.LoggingEnabled.TargetPrefix,
+	}, nil
+}
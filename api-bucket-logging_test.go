@@ -0,0 +1,85 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests setting, getting and disabling bucket access logging.
+func TestBucketLogging(t *testing.T) {
+	stored := []byte(`<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if _, ok := query["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		if _, ok := query["logging"]; !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case "PUT":
+			stored, _ = ioutil.ReadAll(r.Body)
+		case "GET":
+			w.Write(stored)
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	logging, err := clnt.GetBucketLogging("bucket")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if logging.Enabled() {
+		t.Fatalf("Expected logging to be disabled, got %#v", logging)
+	}
+
+	expected := BucketLogging{TargetBucket: "logs", TargetPrefix: "bucket/"}
+	if err = clnt.SetBucketLogging("bucket", expected); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if string(stored) != `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>bucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>` {
+		t.Fatalf("Unexpected request %s", stored)
+	}
+	if logging, err = clnt.GetBucketLogging("bucket"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if logging != expected {
+		t.Fatalf("Expected %#v, got %#v", expected, logging)
+	}
+
+	if err = clnt.SetBucketLogging("bucket", BucketLogging{}); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if logging, err = clnt.GetBucketLogging("bucket"); err != nil || logging.Enabled() {
+		t.Fatalf("Expected logging to be disabled, got %#v %v", logging, err)
+	}
+
+	if err = clnt.SetBucketLogging("bucket", BucketLogging{TargetPrefix: "bucket/"}); err == nil {
+		t.Fatal("Expected prefix without target bucket to fail")
+	}
+}
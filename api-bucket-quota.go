@@ -0,0 +1,112 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// QuotaType - type of a bucket quota.
+type QuotaType string
+
+const (
+	// HardQuota - writes are rejected once the bucket usage reaches
+	// the quota.
+	HardQuota QuotaType = "hard"
+	// FIFOQuota - oldest objects are removed once the bucket usage
+	// exceeds the quota.
+	FIFOQuota QuotaType = "fifo"
+)
+
+// IsValid - verifies if the quota type is supported.
+func (q QuotaType) IsValid() bool {
+	return q == HardQuota || q == FIFOQuota
+}
+
+// BucketQuota - usage quota of a bucket in bytes, a zero quota means
+// the bucket usage is not limited.
+type BucketQuota struct {
+	Quota uint64    `json:"quota"`
+	Type  QuotaType `json:"quotatype,omitempty"`
+}
+
+// SetBucketQuota - sets the quota of a bucket, setting a zero quota
+// removes it. This is a MinIO extension available through the admin
+// API, credentials must be allowed to administer the server.
+func (c Client) SetBucketQuota(bucketName string, quota BucketQuota) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if quota.Quota > 0 && !quota.Type.IsValid() {
+		return ErrInvalidArgument("Quota type should be either hard or fifo.")
+	}
+
+	quotaBytes, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	if c.dryRun("set bucket quota on %s: %s", bucketName, quotaBytes) {
+		return nil
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucketName)
+
+	// Execute PUT on the admin API to set the bucket quota.
+	resp, err := c.executeMethod("PUT", requestMetadata{
+		adminPath:          minioAdminPrefix + "set-bucket-quota",
+		queryValues:        urlValues,
+		contentBody:        bytes.NewReader(quotaBytes),
+		contentLength:      int64(len(quotaBytes)),
+		contentSHA256Bytes: sum256(quotaBytes),
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return adminRespToErrorResponse(resp, bucketName)
+		}
+	}
+	return nil
+}
+
+// GetBucketQuota - gets the quota of a bucket. This is a MinIO
+// extension available through the admin API.
+func (c Client) GetBucketQuota(bucketName string) (BucketQuota, error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return BucketQuota{}, err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucketName)
+
+	var quota BucketQuota
+	if err := c.adminGetJSON("get-bucket-quota", urlValues, bucketName, &quota); err != nil {
+		return BucketQuota{}, err
+	}
+	return quota, nil
+}
@@ -0,0 +1,76 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests setting and getting bucket quotas through the admin API.
+func TestBucketQuota(t *testing.T) {
+	var stored []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Query().Get("bucket") != "bucket" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Code":"XMinioAdminNoSuchBucket","Message":"The specified bucket does not exist."}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /minio/admin/v3/set-bucket-quota":
+			stored, _ = ioutil.ReadAll(r.Body)
+		case "GET /minio/admin/v3/get-bucket-quota":
+			w.Write(stored)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.SetBucketQuota("bucket", BucketQuota{Quota: 1 << 30, Type: HardQuota}); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if string(stored) != `{"quota":1073741824,"quotatype":"hard"}` {
+		t.Fatalf("Unexpected quota %s", stored)
+	}
+	quota, err := clnt.GetBucketQuota("bucket")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if quota.Quota != 1<<30 || quota.Type != HardQuota {
+		t.Fatalf("Unexpected quota %#v", quota)
+	}
+
+	if err = clnt.SetBucketQuota("bucket", BucketQuota{Quota: 1, Type: "soft"}); err == nil {
+		t.Fatal("Expected invalid quota type to fail")
+	}
+	_, err = clnt.GetBucketQuota("other")
+	if errResp := ToErrorResponse(err); errResp.Code != "XMinioAdminNoSuchBucket" || errResp.BucketName != "other" {
+		t.Fatalf("Expected XMinioAdminNoSuchBucket, got %v", err)
+	}
+}
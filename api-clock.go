@@ -0,0 +1,87 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// now - returns the current time corrected by the clock offset
+// learned from the server.
+func (c Client) now() time.Time {
+	t := time.Now().UTC()
+	if c.clockOffset != nil {
+		t = t.Add(time.Duration(atomic.LoadInt64(c.clockOffset)))
+	}
+	return t
+}
+
+// ClockOffset - returns the difference between the server clock and
+// the local clock used to sign requests. The offset is learned from
+// the Date header of responses rejected as RequestTimeTooSkewed, or
+// by SyncClock.
+func (c Client) ClockOffset() time.Duration {
+	if c.clockOffset == nil {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(c.clockOffset))
+}
+
+// SyncClock - learns the clock offset from the Date header of the
+// server, so presigned URLs generated afterwards are valid even if
+// the local clock is wrong.
+func (c Client) SyncClock() error {
+	req, err := http.NewRequest("HEAD", c.endpointURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	c.setUserAgent(req)
+	resp, err := c.do(req)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if !c.adjustClock(resp) {
+		if _, err = http.ParseTime(resp.Header.Get("Date")); err != nil {
+			return ErrInvalidArgument("Server response has no valid Date header.")
+		}
+	}
+	return nil
+}
+
+// adjustClock - sets the clock offset from the Date header of resp,
+// returns true if the offset changed.
+func (c Client) adjustClock(resp *http.Response) bool {
+	if c.clockOffset == nil || resp == nil {
+		return false
+	}
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return false
+	}
+	// The Date header only has second precision, ignore smaller
+	// differences to avoid flapping.
+	offset := serverTime.Sub(time.Now().UTC())
+	if delta := offset - c.ClockOffset(); delta > -time.Second && delta < time.Second {
+		return false
+	}
+	atomic.StoreInt64(c.clockOffset, int64(offset))
+	return true
+}
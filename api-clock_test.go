@@ -0,0 +1,119 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/pkg/credentials"
+)
+
+// Tests the clock offset is learned from skewed request errors.
+func TestClockSkewCorrection(t *testing.T) {
+	serverOffset := time.Hour
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now().UTC().Add(serverOffset)
+		w.Header().Set("Date", now.Format(http.TimeFormat))
+		if r.Method == "HEAD" && r.URL.Path == "/" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		requests++
+		signTime, err := time.Parse(iso8601DateFormat, r.Header.Get("X-Amz-Date"))
+		if err != nil || signTime.Sub(now) > time.Minute || now.Sub(signTime) > time.Minute {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<Error><Code>RequestTimeTooSkewed</Code><Message>The difference between the request time and the server's time is too large.</Message></Error>`))
+			return
+		}
+		w.Write([]byte(`<ListAllMyBucketsResult><Buckets></Buckets></ListAllMyBucketsResult>`))
+	}))
+	defer server.Close()
+
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = clnt.ListBuckets(); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if requests != 2 {
+		t.Fatalf("Expected the request to be signed again once, got %d requests", requests)
+	}
+	if offset := clnt.ClockOffset(); offset < serverOffset-2*time.Second || offset > serverOffset+2*time.Second {
+		t.Fatalf("Expected clock offset of %v, got %v", serverOffset, offset)
+	}
+
+	// SyncClock learns the offset without a failed request.
+	serverOffset = -time.Hour
+	if err = clnt.SyncClock(); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if offset := clnt.ClockOffset(); offset < serverOffset-2*time.Second || offset > serverOffset+2*time.Second {
+		t.Fatalf("Expected clock offset of %v, got %v", serverOffset, offset)
+	}
+	requests = 0
+	if _, err = clnt.ListBuckets(); err != nil || requests != 1 {
+		t.Fatalf("Expected a single request, got %d requests and %v", requests, err)
+	}
+}
+
+// Tests presigning with an explicit signing time and clock skew.
+func TestPresignOptions(t *testing.T) {
+	clnt, err := NewWithRegion("localhost:9000", "access", "secret", false, "us-east-1")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	signTime := time.Date(2017, 6, 1, 10, 0, 0, 0, time.UTC)
+	u, err := clnt.Presign("GET", "bucket", "object", time.Hour, nil, PresignOptions{
+		SignTime:  signTime,
+		ClockSkew: 5 * time.Minute,
+	})
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	query := u.Query()
+	if date := query.Get("X-Amz-Date"); date != "20170601T095500Z" {
+		t.Fatalf("Expected signing time 20170601T095500Z, got %s", date)
+	}
+	if expires := query.Get("X-Amz-Expires"); expires != "4200" {
+		t.Fatalf("Expected expiry of 4200 seconds, got %s", expires)
+	}
+
+	v2Clnt, err := NewWithCredentials("localhost:9000", credentials.NewStaticV2("access", "secret", ""), false, "us-east-1")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	u, err = v2Clnt.Presign("GET", "bucket", "object", time.Hour, nil, PresignOptions{SignTime: signTime, ClockSkew: time.Minute})
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if expires := u.Query().Get("Expires"); expires != "1496314860" {
+		t.Fatalf("Expected expiry at 1496314860, got %s", expires)
+	}
+
+	if _, err = clnt.Presign("GET", "bucket", "object", time.Hour, nil, PresignOptions{ClockSkew: -time.Minute}); err == nil {
+		t.Fatal("Expected negative clock skew to fail")
+	}
+	if _, err = clnt.Presign("GET", "bucket", "object", 7*24*time.Hour, nil, PresignOptions{ClockSkew: time.Minute}); err == nil {
+		t.Fatal("Expected expiry beyond 7 days to fail")
+	}
+}
@@ -27,6 +27,8 @@ type BucketInfo struct {
 	Name string `json:"name"`
 	// Date the bucket was created.
 	CreationDate time.Time `json:"creationDate"`
+	// Region of the bucket, set only when requested while listing.
+	Region string `json:"region,omitempty"`
 }
 
 // ObjectInfo container for object metadata.
@@ -45,6 +47,29 @@ type ObjectInfo struct {
 	// eg: x-amz-meta-*, content-encoding etc.
 	Metadata http.Header `json:"metadata"`
 
+	// User supplied metadata, x-amz-meta-* keys with the prefix removed.
+	UserMetadata map[string]string `json:"userMetadata,omitempty"`
+
+	// Standard headers stored along with the object.
+	ContentEncoding    string    `json:"contentEncoding,omitempty"`
+	ContentDisposition string    `json:"contentDisposition,omitempty"`
+	ContentLanguage    string    `json:"contentLanguage,omitempty"`
+	CacheControl       string    `json:"cacheControl,omitempty"`
+	Expires            time.Time `json:"expires,omitempty"`
+
+	// Version ID of the object, set only on versioned buckets.
+	VersionID string `json:"versionId,omitempty"`
+
+	// Lifecycle expiration as returned in x-amz-expiration, and the
+	// date and the ID of the rule parsed from it.
+	Expiration       string    `json:"expiration,omitempty"`
+	ExpirationTime   time.Time `json:"expirationTime,omitempty"`
+	ExpirationRuleID string    `json:"expirationRuleId,omitempty"`
+
+	// Replication status as returned in x-amz-replication-status,
+	// one of the ReplicationStatus constants.
+	ReplicationStatus string `json:"replicationStatus,omitempty"`
+
 	// Owner name.
 	Owner struct {
 		DisplayName string `json:"name"`
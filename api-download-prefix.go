@@ -0,0 +1,138 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// DownloadResult - outcome of the download of one object by
+// DownloadPrefix.
+type DownloadResult struct {
+	// Name of the object and the local file it was downloaded to.
+	ObjectName string
+	FilePath   string
+	// Size of the object in bytes.
+	Size int64
+	// Set if the download failed, or if listing the objects failed,
+	// in which case ObjectName is empty.
+	Err error
+}
+
+// DownloadPrefix - downloads all objects under prefix in bucket to
+// localDir with up to concurrency downloads in flight, 0 for the
+// default. Keys are recreated as paths below localDir, relative to
+// the last slash of prefix, so prefix "photos/2017/" downloads
+// "photos/2017/jan/a.jpg" to "jan/a.jpg" below localDir. The outcome
+// of every download is sent on the returned channel, which is closed
+// once all objects were downloaded and has to be drained.
+func (c Client) DownloadPrefix(bucketName, prefix, localDir string, concurrency int) <-chan DownloadResult {
+	resultCh := make(chan DownloadResult, 1)
+
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		defer close(resultCh)
+		resultCh <- DownloadResult{Err: err}
+		return resultCh
+	}
+	if err := s3utils.CheckValidObjectNamePrefix(prefix); err != nil {
+		defer close(resultCh)
+		resultCh <- DownloadResult{Err: err}
+		return resultCh
+	}
+	if localDir == "" {
+		defer close(resultCh)
+		resultCh <- DownloadResult{Err: ErrInvalidArgument("Local directory cannot be empty.")}
+		return resultCh
+	}
+	if concurrency < 0 {
+		defer close(resultCh)
+		resultCh <- DownloadResult{Err: ErrInvalidArgument("Concurrency cannot be negative.")}
+		return resultCh
+	}
+	if concurrency == 0 {
+		concurrency = totalWorkers
+	}
+
+	// Keys are made relative to the directory of prefix.
+	base := prefix[:strings.LastIndex(prefix, "/")+1]
+	localDir = filepath.Clean(localDir)
+
+	go func() {
+		defer close(resultCh)
+
+		objectsCh := make(chan ObjectInfo)
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for objInfo := range objectsCh {
+					if result, ok := c.downloadPrefixObject(bucketName, base, localDir, objInfo); ok {
+						resultCh <- result
+					}
+				}
+			}()
+		}
+
+		doneCh := make(chan struct{})
+		defer close(doneCh)
+		for objInfo := range c.ListObjectsV2(bucketName, prefix, true, doneCh) {
+			if objInfo.Err != nil {
+				resultCh <- DownloadResult{Err: objInfo.Err}
+				break
+			}
+			objectsCh <- objInfo
+		}
+		close(objectsCh)
+		wg.Wait()
+	}()
+	return resultCh
+}
+
+// downloadPrefixObject - downloads the object below localDir, objects
+// which map to localDir itself are skipped.
+func (c Client) downloadPrefixObject(bucketName, base, localDir string, objInfo ObjectInfo) (DownloadResult, bool) {
+	result := DownloadResult{
+		ObjectName: objInfo.Key,
+		FilePath:   filepath.Join(localDir, filepath.FromSlash(strings.TrimPrefix(objInfo.Key, base))),
+		Size:       objInfo.Size,
+	}
+	// Keys like "../a" must not be written outside of localDir.
+	rel, err := filepath.Rel(localDir, result.FilePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		result.Err = ErrInvalidArgument("Object " + objInfo.Key + " cannot be downloaded below " + localDir + ".")
+		return result, true
+	}
+	// Such as the directory marker named like the prefix.
+	if rel == "." {
+		return result, false
+	}
+	// Directory markers are created as empty directories.
+	if strings.HasSuffix(objInfo.Key, "/") {
+		result.Err = os.MkdirAll(result.FilePath, 0700)
+		return result, true
+	}
+	result.Err = c.FGetObject(bucketName, objInfo.Key, result.FilePath)
+	return result, true
+}
@@ -0,0 +1,92 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// DryRunOn - enable dry-run mode. Uploads, copies, removals and
+// bucket configuration changes are validated and logged to
+// outputStream instead of being sent to the server. Read-only
+// operations are still sent.
+func (c *Client) DryRunOn(outputStream io.Writer) {
+	// if outputStream is nil then default to os.Stdout.
+	if outputStream == nil {
+		outputStream = os.Stdout
+	}
+	// Sets a new output stream.
+	c.dryRunOutput = outputStream
+
+	// Enable dry-run.
+	c.isDryRun = true
+}
+
+// DryRunOff - disable dry-run mode.
+func (c *Client) DryRunOff() {
+	// Disable dry-run.
+	c.isDryRun = false
+}
+
+// dryRun logs the operation and returns true if dry-run mode is
+// enabled, the caller should then return without sending requests.
+func (c Client) dryRun(format string, args ...interface{}) bool {
+	if !c.isDryRun {
+		return false
+	}
+	fmt.Fprintf(c.dryRunOutput, "DRY-RUN: "+format+"\n", args...)
+	return true
+}
+
+// dryRunPutObject logs how an object of size bytes would be
+// uploaded. The reader is not consumed, size is returned as the
+// number of bytes that would have been uploaded.
+func (c Client) dryRunPutObject(bucketName, objectName string, size int64) (n int64, err error) {
+	// Input validation.
+	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+		return 0, err
+	}
+	if err = s3utils.CheckValidObjectName(objectName); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case size >= 0 && size < minPartSize:
+		c.dryRun("put object %s/%s of %d bytes in a single request", bucketName, objectName, size)
+	case size < 0:
+		_, partSize, _, err := optimalPartInfo(-1)
+		if err != nil {
+			return 0, err
+		}
+		c.dryRun("put object %s/%s of unknown size as multipart upload in parts of %d bytes",
+			bucketName, objectName, partSize)
+		return 0, nil
+	default:
+		totalPartsCount, partSize, lastPartSize, err := optimalPartInfo(size)
+		if err != nil {
+			return 0, err
+		}
+		c.dryRun("put object %s/%s of %d bytes as multipart upload in %d parts of %d bytes, last part %d bytes",
+			bucketName, objectName, size, totalPartsCount, partSize, lastPartSize)
+	}
+	return size, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// Tests mutating operations are not sent in dry-run mode.
+func TestDryRun(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access-key", "secret-key", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	var output bytes.Buffer
+	clnt.DryRunOn(&output)
+
+	if err = clnt.MakeBucket("bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	n, err := clnt.PutObject("bucket", "object", bytes.NewReader(make([]byte, 1024)), "")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if n != 1024 {
+		t.Fatalf("Expected 1024 bytes, got %d", n)
+	}
+	if _, err = clnt.PutObject("bucket", "", bytes.NewReader(nil), ""); err == nil {
+		t.Fatal("Error: invalid object name must fail in dry-run mode")
+	}
+	if err = clnt.CopyObject("bucket", "copy", "bucket/object", NewCopyConditions()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.RemoveObject("bucket", "object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	objectsCh := make(chan string, 2)
+	objectsCh <- "object-1"
+	objectsCh <- "object-2"
+	close(objectsCh)
+	for rErr := range clnt.RemoveObjects("bucket", objectsCh) {
+		t.Fatal("Error:", rErr.Err)
+	}
+	if err = clnt.RemoveBucket("bucket"); err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Fatalf("Expected no requests in dry-run mode, got %d", n)
+	}
+	if lines := strings.Count(output.String(), "DRY-RUN: "); lines != 7 {
+		t.Fatalf("Expected 7 dry-run lines, got %d: %s", lines, output.String())
+	}
+
+	// Multipart plan is logged for large objects.
+	output.Reset()
+	if _, err = clnt.dryRunPutObject("bucket", "object", 200*1024*1024); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if !strings.Contains(output.String(), "in 4 parts of 67108864 bytes, last part 8388608 bytes") {
+		t.Fatalf("Unexpected part plan %s", output.String())
+	}
+
+	clnt.DryRunOff()
+	if err = clnt.RemoveObject("bucket", "object"); err == nil {
+		t.Fatal("Error: remove must reach the server once dry-run is off")
+	}
+	if atomic.LoadInt32(&requests) == 0 {
+		t.Fatal("Error: expected requests once dry-run is off")
+	}
+}
@@ -134,6 +134,13 @@ func httpRespToErrorResponse(resp *http.Response, bucketName, objectName string)
 				Message:    "Bucket not empty.",
 				BucketName: bucketName,
 			}
+		case http.StatusNotModified:
+			errResp = ErrorResponse{
+				Code:       "NotModified",
+				Message:    s3ErrorResponseMap["NotModified"],
+				BucketName: bucketName,
+				Key:        objectName,
+			}
 		case http.StatusPreconditionFailed:
 			errResp = ErrorResponse{
 				Code:       "PreconditionFailed",
@@ -215,6 +222,17 @@ func ErrUnexpectedEOF(totalRead, totalSize int64, bucketName, objectName string)
 	}
 }
 
+// ErrNotModified - Object is unchanged since it was last retrieved.
+func ErrNotModified(bucketName, objectName string) error {
+	return ErrorResponse{
+		Code:       "NotModified",
+		Message:    s3ErrorResponseMap["NotModified"],
+		BucketName: bucketName,
+		Key:        objectName,
+		RequestID:  "minio",
+	}
+}
+
 // ErrInvalidBucketName - Invalid bucket name response.
 func ErrInvalidBucketName(message string) error {
 	return ErrorResponse{
@@ -0,0 +1,211 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// ObjectAttributesChecksum container for checksums of an object or a part.
+type ObjectAttributesChecksum struct {
+	ChecksumCRC32  string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumSHA1   string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256,omitempty"`
+}
+
+// ObjectAttributePart container for a single part of a multipart object.
+type ObjectAttributePart struct {
+	ObjectAttributesChecksum
+
+	// Part number identifies the part.
+	PartNumber int
+
+	// Size of the part data.
+	Size int64
+}
+
+// ObjectAttributesParts container for parts information of a multipart object.
+type ObjectAttributesParts struct {
+	// Total number of parts of the object.
+	PartsCount int `xml:"TotalPartsCount"`
+
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+
+	// Indicates whether the returned list of parts is truncated.
+	IsTruncated bool
+	Parts       []ObjectAttributePart `xml:"Part"`
+}
+
+// ObjectAttributes container for the attributes of an object returned
+// by GetObjectAttributes.
+type ObjectAttributes struct {
+	// ETag of the object, without the surrounding double quotes.
+	ETag string
+
+	// Checksum of the object, if any was computed.
+	Checksum ObjectAttributesChecksum
+
+	// Parts of the object, PartsCount is zero for objects which were
+	// not uploaded as multipart.
+	ObjectParts ObjectAttributesParts
+
+	// The class of storage used to store the object.
+	StorageClass string
+
+	// Size in bytes of the object.
+	ObjectSize int64
+
+	// Date and time the object was last modified.
+	LastModified time.Time `xml:"-"`
+
+	// Version ID of the object, if versioning is enabled on the bucket.
+	VersionID string `xml:"-"`
+}
+
+// List of all the attributes requested from the server.
+var objectAttributesList = []string{
+	"ETag",
+	"Checksum",
+	"ObjectParts",
+	"StorageClass",
+	"ObjectSize",
+}
+
+// GetObjectAttributes - returns ETag, checksum, parts information,
+// storage class and size of an object in a single request. Parts
+// information is gathered in full, the request is repeated for objects
+// with more parts than returned in one response.
+func (c Client) GetObjectAttributes(bucketName, objectName string) (ObjectAttributes, error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return ObjectAttributes{}, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return ObjectAttributes{}, err
+	}
+
+	var attrs ObjectAttributes
+	var parts []ObjectAttributePart
+
+	// Part number marker for the next batch of request.
+	var partNumberMarker int
+	for {
+		result, err := c.getObjectAttributesQuery(bucketName, objectName, partNumberMarker, 1000)
+		if err != nil {
+			return ObjectAttributes{}, err
+		}
+		parts = append(parts, result.ObjectParts.Parts...)
+		attrs = result
+		// Listing ends result is not truncated, return right here.
+		if !result.ObjectParts.IsTruncated {
+			break
+		}
+		// A marker which does not advance would repeat the request forever.
+		if result.ObjectParts.NextPartNumberMarker <= partNumberMarker {
+			return ObjectAttributes{}, ErrorResponse{
+				Code:       "InvalidResponse",
+				Message:    fmt.Sprintf("Truncated parts of object %s have next part number marker %d, which does not follow %d.", objectName, result.ObjectParts.NextPartNumberMarker, partNumberMarker),
+				BucketName: bucketName,
+				Key:        objectName,
+				RequestID:  "minio",
+			}
+		}
+		partNumberMarker = result.ObjectParts.NextPartNumberMarker
+	}
+
+	attrs.ObjectParts.Parts = parts
+	attrs.ObjectParts.IsTruncated = false
+	return attrs, nil
+}
+
+// getObjectAttributesQuery - (Get Object Attributes) - returns attributes
+// of an object and up to maxParts parts after partNumberMarker.
+func (c Client) getObjectAttributesQuery(bucketName, objectName string, partNumberMarker, maxParts int) (ObjectAttributes, error) {
+	// Get resources properly escaped and lined up before using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set("attributes", "")
+
+	// Set the attributes to be returned and the parts to list.
+	customHeader := make(http.Header)
+	customHeader.Set("X-Amz-Object-Attributes", strings.Join(objectAttributesList, ","))
+	if partNumberMarker > 0 {
+		customHeader.Set("X-Amz-Part-Number-Marker", strconv.Itoa(partNumberMarker))
+	}
+
+	// maxParts should be 1000 or less.
+	if maxParts == 0 || maxParts > 1000 {
+		maxParts = 1000
+	}
+	customHeader.Set("X-Amz-Max-Parts", strconv.Itoa(maxParts))
+
+	// Execute GET on objectName to get its attributes.
+	resp, err := c.executeMethod("GET", requestMetadata{
+		bucketName:         bucketName,
+		objectName:         objectName,
+		queryValues:        urlValues,
+		customHeader:       customHeader,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return ObjectAttributes{}, err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return ObjectAttributes{}, httpRespToErrorResponse(resp, bucketName, objectName)
+		}
+	}
+
+	// Decode object attributes XML.
+	attrs := ObjectAttributes{}
+	if err = xmlDecoder(resp.Body, &attrs); err != nil {
+		return ObjectAttributes{}, err
+	}
+
+	// Trim off the odd double quotes from ETag in the beginning and end.
+	attrs.ETag = strings.TrimPrefix(attrs.ETag, "\"")
+	attrs.ETag = strings.TrimSuffix(attrs.ETag, "\"")
+
+	// Last-Modified and version id are only returned as headers.
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+		attrs.LastModified, err = time.Parse(http.TimeFormat, lastModified)
+		if err != nil {
+			return ObjectAttributes{}, ErrorResponse{
+				Code:       "InternalError",
+				Message:    "Last-Modified time format is invalid. " + reportIssue,
+				BucketName: bucketName,
+				Key:        objectName,
+				RequestID:  resp.Header.Get("x-amz-request-id"),
+				HostID:     resp.Header.Get("x-amz-id-2"),
+				Region:     resp.Header.Get("x-amz-bucket-region"),
+			}
+		}
+	}
+	attrs.VersionID = resp.Header.Get("x-amz-version-id")
+	return attrs, nil
+}
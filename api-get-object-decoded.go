@@ -0,0 +1,154 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// DecodeOptions - options of GetDecodedObject.
+type DecodeOptions struct {
+	// DisableDecompression returns the content as stored, with
+	// the sizes still being reported.
+	DisableDecompression bool
+}
+
+// DecodedObject - streams the content of an object decompressed
+// according to its Content-Encoding.
+type DecodedObject struct {
+	body   io.ReadCloser
+	reader io.Reader
+
+	objectInfo   ObjectInfo
+	decompressed bool
+
+	// Number of bytes handed out to the caller.
+	decodedSize *int64
+}
+
+// countingReader - counts the bytes read from reader into n.
+type countingReader struct {
+	reader io.Reader
+	n      *int64
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	atomic.AddInt64(r.n, int64(n))
+	return n, err
+}
+
+// newContentDecoder - returns a reader decoding body encoded with
+// contentEncoding, ok is false for encodings other than gzip and
+// deflate.
+func newContentDecoder(body io.Reader, contentEncoding string) (reader io.Reader, ok bool, err error) {
+	// The last encoding applied is listed last, it is the only one
+	// taken off as objects are not expected to be encoded twice.
+	encodings := strings.Split(contentEncoding, ",")
+	switch strings.ToLower(strings.TrimSpace(encodings[len(encodings)-1])) {
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(body)
+	case "deflate":
+		reader, err = zlib.NewReader(body)
+	default:
+		return body, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return reader, true, nil
+}
+
+// GetDecodedObject - downloads an object decompressing objects stored
+// with 'Content-Encoding: gzip' or 'deflate' while the caller reads,
+// content with other encodings is returned as stored. Unlike GetObject
+// the returned stream is not seekable, it should be closed by the
+// caller.
+func (c Client) GetDecodedObject(bucketName, objectName string, opts DecodeOptions) (*DecodedObject, error) {
+	// Ask for the content as stored, otherwise the http client might
+	// decompress it on its own and drop the Content-Encoding header.
+	reqHeaders := NewGetReqHeaders()
+	reqHeaders.Set("Accept-Encoding", "identity")
+
+	body, objInfo, err := c.getObject(bucketName, objectName, reqHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := io.Reader(body)
+	decompressed := false
+	if !opts.DisableDecompression {
+		reader, decompressed, err = newContentDecoder(body, objInfo.ContentEncoding)
+		if err != nil {
+			body.Close()
+			return nil, ErrorResponse{
+				Code:       "InvalidEncoding",
+				Message:    "Object content is not valid " + objInfo.ContentEncoding + ": " + err.Error(),
+				BucketName: bucketName,
+				Key:        objectName,
+			}
+		}
+	}
+
+	var decodedSize int64
+	return &DecodedObject{
+		body:         body,
+		reader:       countingReader{reader, &decodedSize},
+		objectInfo:   objInfo,
+		decompressed: decompressed,
+		decodedSize:  &decodedSize,
+	}, nil
+}
+
+// Read - reads decoded content.
+func (o *DecodedObject) Read(p []byte) (int, error) {
+	return o.reader.Read(p)
+}
+
+// Close - closes the underlying connection.
+func (o *DecodedObject) Close() error {
+	return o.body.Close()
+}
+
+// Stat - returns the metadata of the object, its Size is the size of
+// the content as stored.
+func (o *DecodedObject) Stat() ObjectInfo {
+	return o.objectInfo
+}
+
+// Decompressed - tells whether the content is decompressed.
+func (o *DecodedObject) Decompressed() bool {
+	return o.decompressed
+}
+
+// CompressedSize - returns the size of the content as stored.
+func (o *DecodedObject) CompressedSize() int64 {
+	return o.objectInfo.Size
+}
+
+// DecompressedSize - returns the number of decoded bytes read so far,
+// which is the decompressed size of the object once Read returned
+// io.EOF. Compressed formats do not reliably record the original size,
+// hence it is not known before.
+func (o *DecodedObject) DecompressedSize() int64 {
+	return atomic.LoadInt64(o.decodedSize)
+}
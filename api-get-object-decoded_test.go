@@ -0,0 +1,95 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Tests gzip encoded objects are decompressed on request.
+func TestGetDecodedObject(t *testing.T) {
+	content := bytes.Repeat([]byte("hello world "), 1000)
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	zw.Write(content)
+	zw.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		if r.Header.Get("Accept-Encoding") != "identity" {
+			t.Errorf("Unexpected Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(compressed.Len()))
+		if r.URL.Path == "/bucket/gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(compressed.Bytes())
+			return
+		}
+		w.Header().Set("Content-Encoding", "br")
+		w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	testCases := []struct {
+		objectName   string
+		opts         DecodeOptions
+		decompressed bool
+		data         []byte
+	}{
+		{"gzip", DecodeOptions{}, true, content},
+		{"gzip", DecodeOptions{DisableDecompression: true}, false, compressed.Bytes()},
+		{"brotli", DecodeOptions{}, false, compressed.Bytes()},
+	}
+	for i, testCase := range testCases {
+		object, err := clnt.GetDecodedObject("bucket", testCase.objectName, testCase.opts)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		data, err := ioutil.ReadAll(object)
+		object.Close()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !bytes.Equal(data, testCase.data) {
+			t.Errorf("Test %d: unexpected content of %d bytes", i+1, len(data))
+		}
+		if object.Decompressed() != testCase.decompressed {
+			t.Errorf("Test %d: expected decompressed %v", i+1, testCase.decompressed)
+		}
+		if object.CompressedSize() != int64(compressed.Len()) || object.DecompressedSize() != int64(len(testCase.data)) {
+			t.Errorf("Test %d: unexpected sizes %d, %d", i+1, object.CompressedSize(), object.DecompressedSize())
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package minio
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
@@ -24,8 +25,24 @@ import (
 	"github.com/minio/minio-go/pkg/s3utils"
 )
 
+// FGetObjectOptions - options of FGetObjectWithOptions.
+type FGetObjectOptions struct {
+	// Number of bytes at the end of a partial download verified
+	// against the object before the download is resumed, 0 resumes
+	// on the size of the partial download alone. Partial downloads
+	// which do not match are discarded and downloaded again.
+	VerifyTail int64
+}
+
 // FGetObject - download contents of an object to a local file.
 func (c Client) FGetObject(bucketName, objectName, filePath string) error {
+	return c.FGetObjectWithOptions(bucketName, objectName, filePath, FGetObjectOptions{})
+}
+
+// FGetObjectWithOptions - download contents of an object to a local
+// file. The object is first downloaded to a part file next to filePath,
+// an interrupted download resumes from the end of the part file.
+func (c Client) FGetObjectWithOptions(bucketName, objectName, filePath string, opts FGetObjectOptions) error {
 	// Input validation.
 	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
 		return err
@@ -33,6 +50,9 @@ func (c Client) FGetObject(bucketName, objectName, filePath string) error {
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
 		return err
 	}
+	if opts.VerifyTail < 0 {
+		return ErrInvalidArgument("Verified tail size cannot be negative.")
+	}
 
 	// Verify if destination already exists.
 	st, err := os.Stat(filePath)
@@ -68,11 +88,12 @@ func (c Client) FGetObject(bucketName, objectName, filePath string) error {
 	// Write to a temporary file "fileName.part.minio" before saving.
 	filePartPath := filePath + objectStat.ETag + ".part.minio"
 
-	// If exists, open in append mode. If not create it as a part file.
-	filePart, err := os.OpenFile(filePartPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	// If exists, open it to resume. If not create it as a part file.
+	filePart, err := os.OpenFile(filePartPath, os.O_CREATE|os.O_RDWR, 0600)
 	if err != nil {
 		return err
 	}
+	defer filePart.Close()
 
 	// Issue Stat to get the current offset.
 	st, err = filePart.Stat()
@@ -80,22 +101,23 @@ func (c Client) FGetObject(bucketName, objectName, filePath string) error {
 		return err
 	}
 
-	// Initialize get object request headers to set the
-	// appropriate range offsets to read from.
-	reqHeaders := NewGetReqHeaders()
-	if st.Size() > 0 {
-		reqHeaders.SetRange(st.Size(), 0)
-	}
-
-	// Seek to current position for incoming reader.
-	objectReader, objectStat, err := c.getObject(bucketName, objectName, reqHeaders)
-	if err != nil {
-		return err
+	// Resume only from a partial download matching the object.
+	offset := st.Size()
+	if offset > 0 {
+		var ok bool
+		ok, err = c.verifyPartialDownload(bucketName, objectName, filePart, offset, objectStat, opts.VerifyTail)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			offset = 0
+		}
 	}
 
-	// Write to the part file.
-	if _, err = io.CopyN(filePart, objectReader, objectStat.Size); err != nil {
-		return err
+	if offset < objectStat.Size || objectStat.Size == 0 {
+		if err = c.fGetObjectFrom(bucketName, objectName, filePart, offset, objectStat); err != nil {
+			return err
+		}
 	}
 
 	// Close the file before rename, this is specifically needed for Windows users.
@@ -111,3 +133,83 @@ func (c Client) FGetObject(bucketName, objectName, filePath string) error {
 	// Return.
 	return nil
 }
+
+// verifyPartialDownload - verifies a partial download of offset bytes
+// can be resumed, tail bytes at its end being compared to the object.
+func (c Client) verifyPartialDownload(bucketName, objectName string, filePart *os.File, offset int64, objectStat ObjectInfo, tail int64) (bool, error) {
+	if offset > objectStat.Size {
+		return false, nil
+	}
+	if tail == 0 {
+		return true, nil
+	}
+	if tail > offset {
+		tail = offset
+	}
+
+	local := make([]byte, tail)
+	if _, err := filePart.ReadAt(local, offset-tail); err != nil {
+		return false, err
+	}
+
+	reqHeaders := NewGetReqHeaders()
+	if err := reqHeaders.SetMatchETag(objectStat.ETag); err != nil {
+		return false, err
+	}
+	if err := reqHeaders.SetRange(offset-tail, offset-1); err != nil {
+		return false, err
+	}
+	objectReader, tailStat, err := c.getObject(bucketName, objectName, reqHeaders)
+	if err != nil {
+		return false, err
+	}
+	defer objectReader.Close()
+	if tailStat.Size != tail {
+		// Range was not honored, the tail cannot be verified.
+		return false, nil
+	}
+	remote := make([]byte, tail)
+	if _, err = io.ReadFull(objectReader, remote); err != nil {
+		return false, err
+	}
+	return bytes.Equal(local, remote), nil
+}
+
+// fGetObjectFrom - downloads the object to filePart from offset onwards.
+func (c Client) fGetObjectFrom(bucketName, objectName string, filePart *os.File, offset int64, objectStat ObjectInfo) error {
+	// Initialize get object request headers to set the
+	// appropriate range offsets to read from, the object must
+	// not change between the partial downloads.
+	reqHeaders := NewGetReqHeaders()
+	if err := reqHeaders.SetMatchETag(objectStat.ETag); err != nil {
+		return err
+	}
+	if offset > 0 {
+		reqHeaders.SetRange(offset, 0)
+	}
+
+	// Seek to current position for incoming reader.
+	objectReader, rangeStat, err := c.getObject(bucketName, objectName, reqHeaders)
+	if err != nil {
+		return err
+	}
+	defer objectReader.Close()
+
+	if rangeStat.Size != objectStat.Size-offset {
+		if rangeStat.Size != objectStat.Size {
+			return ErrUnexpectedEOF(rangeStat.Size, objectStat.Size-offset, bucketName, objectName)
+		}
+		// Range was not honored, the whole object is sent.
+		offset = 0
+	}
+	if err = filePart.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err = filePart.Seek(offset, 0); err != nil {
+		return err
+	}
+
+	// Write to the part file.
+	_, err = io.CopyN(filePart, objectReader, rangeStat.Size)
+	return err
+}
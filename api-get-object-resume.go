@@ -0,0 +1,188 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resumingReader - reads the body of an object download, resuming
+// with a ranged GET from the current offset when the body fails mid
+// stream. Resumed downloads are conditioned on the ETag of the
+// object, so that data of a replaced object is never spliced in.
+// Consecutive failures are bounded by the retry policy of the client.
+type resumingReader struct {
+	c          Client
+	bucketName string
+	objectName string
+	reqHeaders RequestHeaders
+	etag       string
+
+	// Offset of the next byte to read, and of the last byte of the
+	// requested range, -1 for the end of the object.
+	offset int64
+	end    int64
+	// Offset the download started at and its expected length.
+	start int64
+	size  int64
+
+	body    io.ReadCloser
+	attempt int
+	// Error reading ended with, returned by all later reads.
+	err error
+}
+
+// newResumingReader - returns body resuming on failures, or body itself
+// if the download cannot be resumed safely.
+func (c Client) newResumingReader(bucketName, objectName string, reqHeaders RequestHeaders, objectInfo ObjectInfo, body io.ReadCloser) io.ReadCloser {
+	start, end, ok := parseRequestRange(reqHeaders.Get("Range"))
+	if !ok || objectInfo.ETag == "" {
+		return body
+	}
+	return &resumingReader{
+		c:          c,
+		bucketName: bucketName,
+		objectName: objectName,
+		reqHeaders: reqHeaders,
+		etag:       objectInfo.ETag,
+		offset:     start,
+		end:        end,
+		start:      start,
+		size:       objectInfo.Size,
+		body:       body,
+	}
+}
+
+// parseRequestRange - returns the first and last byte of the range
+// requested, the last byte being -1 for open ranges. Suffix ranges
+// are not supported.
+func parseRequestRange(value string) (start, end int64, ok bool) {
+	if value == "" {
+		return 0, -1, true
+	}
+	if !strings.HasPrefix(value, "bytes=") {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, "bytes="), "-", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if parts[1] == "" {
+		return start, -1, true
+	}
+	end, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// Read implements io.Reader.
+func (r *resumingReader) Read(p []byte) (n int, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	for {
+		n, err = r.body.Read(p)
+		r.offset += int64(n)
+		if n > 0 {
+			// Progress was made, failures are counted afresh.
+			r.attempt = 0
+		}
+		if err == nil || err == io.EOF || !isResumableReadError(err) {
+			return n, err
+		}
+		if err = r.resume(err); err != nil {
+			r.err = err
+			return n, err
+		}
+		if n > 0 || len(p) == 0 {
+			return n, nil
+		}
+	}
+}
+
+// isResumableReadError - tells whether reading a body failed because
+// of the connection.
+func isResumableReadError(err error) bool {
+	return err == io.ErrUnexpectedEOF || isNetErrorRetryable(err)
+}
+
+// resume - replaces the failed body by the remainder of the range
+// requested, if the retry policy agrees.
+func (r *resumingReader) resume(readErr error) error {
+	r.body.Close()
+	if r.end >= 0 && r.offset > r.end || r.size >= 0 && r.offset-r.start >= r.size {
+		// Everything was read after all.
+		r.body = eofReader{}
+		return nil
+	}
+
+	r.attempt++
+	policy := r.c.getRetryPolicy()
+	if !policy.ShouldRetry(r.attempt, nil, nil, readErr) {
+		if readErr == io.ErrUnexpectedEOF {
+			// Readers take a bare ErrUnexpectedEOF for the end of
+			// a short object, report the truncation instead.
+			return ErrUnexpectedEOF(r.offset-r.start, r.size, r.bucketName, r.objectName)
+		}
+		return readErr
+	}
+	time.Sleep(policy.Backoff(r.attempt))
+
+	reqHeaders := NewGetReqHeaders()
+	for key, value := range r.reqHeaders.Header {
+		reqHeaders.Header[key] = value
+	}
+	if err := reqHeaders.SetMatchETag(r.etag); err != nil {
+		return err
+	}
+	if r.end >= 0 {
+		reqHeaders.SetRange(r.offset, r.end)
+	} else if r.offset > 0 {
+		reqHeaders.SetRange(r.offset, 0)
+	}
+	body, _, err := r.c.getObject(r.bucketName, r.objectName, reqHeaders)
+	if err != nil {
+		return err
+	}
+	r.body = body
+	return nil
+}
+
+// Close implements io.Closer.
+func (r *resumingReader) Close() error {
+	if r.err != nil {
+		// Failed body was closed already.
+		return nil
+	}
+	return r.body.Close()
+}
+
+// eofReader - body of a download which is over.
+type eofReader struct{}
+
+func (eofReader) Read(p []byte) (int, error) { return 0, io.EOF }
+func (eofReader) Close() error               { return nil }
@@ -0,0 +1,179 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFlakyServer - returns a server of an object whose downloads break
+// off after cut bytes for the first failures requests.
+func newFlakyServer(data string, cut, failures int, etags func(int) string) (*httptest.Server, *[]http.Header) {
+	var mutex sync.Mutex
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		mutex.Lock()
+		headers = append(headers, r.Header)
+		request := len(headers)
+		mutex.Unlock()
+
+		etag := etags(request)
+		if match := r.Header.Get("If-Match"); match != "" && strings.Trim(match, `"`) != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`<Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`))
+			return
+		}
+		content := data
+		status := http.StatusOK
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			content = data[start:]
+			status = http.StatusPartialContent
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(data)-1, len(data)))
+		}
+		w.Header().Set("ETag", `"`+etag+`"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.WriteHeader(status)
+		if request <= failures && len(content) > cut {
+			// Connection is closed short of Content-Length.
+			content = content[:cut]
+		}
+		w.Write([]byte(content))
+	}))
+	return server, &headers
+}
+
+// Tests downloads broken off mid stream are resumed.
+func TestGetObjectResume(t *testing.T) {
+	data := strings.Repeat("0123456789", 1000)
+	etag := func(int) string { return "etag" }
+
+	testCases := []struct {
+		failures int
+		maxRetry int
+		etags    func(int) string
+		errCode  string
+		requests int
+	}{
+		// Resumed twice.
+		{2, 2, etag, "", 3},
+		// Every resume makes progress, failures do not add up.
+		{3, 2, etag, "", 4},
+		// Retry policy gives up.
+		{2, 1, etag, "UnexpectedEOF", 1},
+		// Object replaced in between, the failed resume is retried
+		// like any other request.
+		{1, 2, func(request int) string { return fmt.Sprintf("etag-%d", request) }, "PreconditionFailed", 3},
+	}
+	for i, testCase := range testCases {
+		server, headers := newFlakyServer(data, 3000, testCase.failures, testCase.etags)
+		clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		clnt.SetRetryPolicy(&countingRetryPolicy{maxRetry: testCase.maxRetry})
+
+		object, err := clnt.GetObject("bucket", "object")
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		content, err := ioutil.ReadAll(object)
+		object.Close()
+		server.Close()
+
+		if testCase.errCode != "" {
+			if ToErrorResponse(err).Code != testCase.errCode {
+				t.Errorf("Test %d: expected %s, got %v", i+1, testCase.errCode, err)
+			}
+		} else if err != nil || string(content) != data {
+			t.Errorf("Test %d: expected the object, got %d bytes, %v", i+1, len(content), err)
+		}
+		if len(*headers) != testCase.requests {
+			t.Errorf("Test %d: expected %d requests, got %d", i+1, testCase.requests, len(*headers))
+		}
+		for j, header := range (*headers)[1:] {
+			offset := 3000 * (j + 1)
+			if testCase.errCode != "" {
+				offset = 3000
+			}
+			if expected := fmt.Sprintf("bytes=%d-", offset); header.Get("Range") != expected {
+				t.Errorf("Test %d: expected range %s, got %s", i+1, expected, header.Get("Range"))
+			}
+			if header.Get("If-Match") != `"etag"` && header.Get("If-Match") != `"etag-1"` {
+				t.Errorf("Test %d: expected ETag condition, got %q", i+1, header.Get("If-Match"))
+			}
+		}
+	}
+}
+
+// brokenReader - fails every read with err.
+type brokenReader struct {
+	err error
+}
+
+func (r brokenReader) Read(p []byte) (int, error) { return 0, r.err }
+
+// Tests downloads failing after all of an open range was read are not
+// resumed.
+func TestGetObjectResumeComplete(t *testing.T) {
+	body := ioutil.NopCloser(io.MultiReader(strings.NewReader("data"), brokenReader{io.ErrUnexpectedEOF}))
+	// Any request would fail, the client has no endpoint.
+	reader := &resumingReader{start: 2, offset: 2, end: -1, size: 4, body: body}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil || string(content) != "data" {
+		t.Fatalf("Expected the download to be complete, got %q, %v", content, err)
+	}
+}
+
+// Tests request ranges downloads can be resumed from.
+func TestParseRequestRange(t *testing.T) {
+	testCases := []struct {
+		value      string
+		start, end int64
+		ok         bool
+	}{
+		{"", 0, -1, true},
+		{"bytes=10-", 10, -1, true},
+		{"bytes=10-20", 10, 20, true},
+		// Suffix ranges are not resumed.
+		{"bytes=-10", 0, 0, false},
+		{"bytes=a-", 0, 0, false},
+		{"items=1-2", 0, 0, false},
+	}
+	for i, testCase := range testCases {
+		start, end, ok := parseRequestRange(testCase.value)
+		if start != testCase.start || end != testCase.end || ok != testCase.ok {
+			t.Errorf("Test %d: expected %d, %d, %v, got %d, %d, %v", i+1,
+				testCase.start, testCase.end, testCase.ok, start, end, ok)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"sync"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// readBufferPool holds buffers of optimalReadBufferSize used for
+// copying object data to writers.
+var readBufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, optimalReadBufferSize)
+		return &buf
+	},
+}
+
+// GetObjectToWriter - streams the contents of an object to w with a
+// single request, using large pooled buffers. It returns the number
+// of bytes written.
+func (c Client) GetObjectToWriter(bucketName, objectName string, w io.Writer) (n int64, err error) {
+	// Input validation.
+	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+		return 0, err
+	}
+	if err = s3utils.CheckValidObjectName(objectName); err != nil {
+		return 0, err
+	}
+	if w == nil {
+		return 0, ErrInvalidArgument("Writer cannot be nil.")
+	}
+
+	reader, objectInfo, err := c.getObject(bucketName, objectName, NewGetReqHeaders())
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	bufp := readBufferPool.Get().(*[]byte)
+	defer readBufferPool.Put(bufp)
+
+	// Hide io.WriterTo of the reader so the pooled buffer is used.
+	n, err = io.CopyBuffer(w, struct{ io.Reader }{reader}, *bufp)
+	if err != nil {
+		return n, err
+	}
+	if objectInfo.Size > -1 && n != objectInfo.Size {
+		return n, ErrUnexpectedEOF(n, objectInfo.Size, bucketName, objectName)
+	}
+	return n, nil
+}
@@ -0,0 +1,94 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// bytesObjectSource serves an object from memory, ranges are ignored.
+type bytesObjectSource []byte
+
+func (b bytesObjectSource) GetObject(reqHeaders RequestHeaders) (io.ReadCloser, ObjectInfo, error) {
+	return ioutil.NopCloser(bytes.NewReader(b)), b.info(), nil
+}
+
+func (b bytesObjectSource) StatObject(reqHeaders RequestHeaders) (ObjectInfo, error) {
+	return b.info(), nil
+}
+
+func (b bytesObjectSource) info() ObjectInfo {
+	return ObjectInfo{ETag: "etag", Size: int64(len(b))}
+}
+
+// Tests copying an object to a writer through io.WriterTo.
+func TestObjectWriteTo(t *testing.T) {
+	data := bytes.Repeat([]byte("minio"), 3*1024*1024)
+	obj := NewObjectReader(bytesObjectSource(data))
+	defer obj.Close()
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, obj)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if n != int64(len(data)) || !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("Expected %d bytes, got %d", len(data), n)
+	}
+}
+
+// Tests streaming an object to a writer with a single request.
+func TestGetObjectToWriter(t *testing.T) {
+	data := bytes.Repeat([]byte("minio"), 3*1024*1024)
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		requests++
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "", "", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	var buf bytes.Buffer
+	n, err := clnt.GetObjectToWriter("bucket", "object", &buf)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if n != int64(len(data)) || !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("Expected %d bytes, got %d", len(data), n)
+	}
+	if requests != 1 {
+		t.Fatalf("Expected a single request, got %d", requests)
+	}
+}
@@ -65,6 +65,44 @@ func (c Client) GetObject(bucketName, objectName string) (*Object, error) {
 		return nil, err
 	}
 
+	return NewObjectReader(clientObjectSource{
+		c:          c,
+		bucketName: bucketName,
+		objectName: objectName,
+	}), nil
+}
+
+// ObjectSource serves data and metadata of a single object honoring
+// the range and precondition headers set in reqHeaders.
+type ObjectSource interface {
+	GetObject(reqHeaders RequestHeaders) (io.ReadCloser, ObjectInfo, error)
+	StatObject(reqHeaders RequestHeaders) (ObjectInfo, error)
+}
+
+// clientObjectSource serves an object from the server.
+type clientObjectSource struct {
+	c          Client
+	bucketName string
+	objectName string
+}
+
+func (s clientObjectSource) GetObject(reqHeaders RequestHeaders) (io.ReadCloser, ObjectInfo, error) {
+	body, objectInfo, err := s.c.getObject(s.bucketName, s.objectName, reqHeaders)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	// Resume reading from where the body broke off.
+	return s.c.newResumingReader(s.bucketName, s.objectName, reqHeaders, objectInfo, body), objectInfo, nil
+}
+
+func (s clientObjectSource) StatObject(reqHeaders RequestHeaders) (ObjectInfo, error) {
+	return s.c.statObject(s.bucketName, s.objectName, reqHeaders)
+}
+
+// NewObjectReader returns a seekable, readable object fetching data from
+// src lazily as the caller reads. This allows alternate backends to hand
+// out objects behaving exactly like the ones returned by GetObject.
+func NewObjectReader(src ObjectSource) *Object {
 	var httpReader io.ReadCloser
 	var objectInfo ObjectInfo
 	var err error
@@ -110,14 +148,14 @@ func (c Client) GetObject(bucketName, objectName string) (*Object, error) {
 							// Do not set objectInfo from the first readAt request because it will not get
 							// the whole object.
 							reqHeaders.SetRange(req.Offset, req.Offset+int64(len(req.Buffer))-1)
-							httpReader, objectInfo, err = c.getObject(bucketName, objectName, reqHeaders)
+							httpReader, objectInfo, err = src.GetObject(reqHeaders)
 						} else {
 							if req.Offset > 0 {
 								reqHeaders.SetRange(req.Offset, 0)
 							}
 
 							// First request is a Read request.
-							httpReader, objectInfo, err = c.getObject(bucketName, objectName, reqHeaders)
+							httpReader, objectInfo, err = src.GetObject(reqHeaders)
 						}
 						if err != nil {
 							resCh <- getResponse{
@@ -144,7 +182,7 @@ func (c Client) GetObject(bucketName, objectName string) (*Object, error) {
 					} else {
 						// First request is a Stat or Seek call.
 						// Only need to run a StatObject until an actual Read or ReadAt request comes through.
-						objectInfo, err = c.StatObject(bucketName, objectName)
+						objectInfo, err = src.StatObject(NewHeadReqHeaders())
 						if err != nil {
 							resCh <- getResponse{
 								Error: err,
@@ -163,7 +201,7 @@ func (c Client) GetObject(bucketName, objectName string) (*Object, error) {
 					if etag != "" {
 						reqHeaders.SetMatchETag(etag)
 					}
-					objectInfo, err := c.statObject(bucketName, objectName, reqHeaders)
+					objectInfo, err := src.StatObject(reqHeaders)
 					if err != nil {
 						resCh <- getResponse{
 							Error: err,
@@ -195,14 +233,14 @@ func (c Client) GetObject(bucketName, objectName string) (*Object, error) {
 						if req.isReadAt {
 							// Range is set with respect to the offset and length of the buffer requested.
 							reqHeaders.SetRange(req.Offset, req.Offset+int64(len(req.Buffer))-1)
-							httpReader, _, err = c.getObject(bucketName, objectName, reqHeaders)
+							httpReader, _, err = src.GetObject(reqHeaders)
 						} else {
 							// Range is set with respect to the offset.
 							if req.Offset > 0 {
 								reqHeaders.SetRange(req.Offset, 0)
 							}
 
-							httpReader, objectInfo, err = c.getObject(bucketName, objectName, reqHeaders)
+							httpReader, objectInfo, err = src.GetObject(reqHeaders)
 						}
 						if err != nil {
 							resCh <- getResponse{
@@ -233,7 +271,7 @@ func (c Client) GetObject(bucketName, objectName string) (*Object, error) {
 	}()
 
 	// Create a newObject through the information sent back by reqCh.
-	return newObject(reqCh, resCh, doneCh), nil
+	return newObject(reqCh, resCh, doneCh)
 }
 
 // get request message container to communicate with internal
@@ -390,6 +428,40 @@ func (o *Object) Read(b []byte) (n int, err error) {
 	return response.Size, err
 }
 
+// WriteTo writes the object data from the current offset to w until
+// there is no more data or an error occurs, reading in large pooled
+// buffers. It returns the number of bytes written and implements
+// io.WriterTo, hence io.Copy uses it automatically.
+func (o *Object) WriteTo(w io.Writer) (n int64, err error) {
+	if o == nil {
+		return 0, ErrInvalidArgument("Object is nil")
+	}
+
+	bufp := readBufferPool.Get().(*[]byte)
+	defer readBufferPool.Put(bufp)
+	buf := *bufp
+
+	for {
+		nr, rerr := o.Read(buf)
+		if nr > 0 {
+			nw, werr := w.Write(buf[:nr])
+			n += int64(nw)
+			if werr != nil {
+				return n, werr
+			}
+			if nw != nr {
+				return n, io.ErrShortWrite
+			}
+		}
+		if rerr == io.EOF {
+			return n, nil
+		}
+		if rerr != nil {
+			return n, rerr
+		}
+	}
+}
+
 // Stat returns the ObjectInfo structure describing Object.
 func (o *Object) Stat() (ObjectInfo, error) {
 	if o == nil {
@@ -685,6 +757,8 @@ func (c Client) getObject(bucketName, objectName string, reqHeaders RequestHeade
 	objectStat.Size = resp.ContentLength
 	objectStat.LastModified = date
 	objectStat.ContentType = contentType
+	objectStat.Metadata = extractObjMetadata(resp.Header)
+	setObjInfoHeaders(&objectStat, resp.Header)
 
 	// do not close body here, caller will close
 	return resp.Body, objectStat, nil
@@ -0,0 +1,125 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Endpoint health states.
+const (
+	healthUnknown int32 = iota
+	healthOffline
+	healthOnline
+)
+
+// Minio readiness probe, other S3 compatible servers answer
+// this path with an ordinary error response.
+const healthCheckPath = "/minio/health/ready"
+
+// Default interval between two health probes.
+const defaultHealthCheckInterval = 5 * time.Second
+
+// HealthCheck probes the endpoint every interval in the background
+// until doneCh is closed. Current reachability is reported by
+// IsOnline and IsOffline. Only one health check may run at a
+// time per client.
+func (c Client) HealthCheck(interval time.Duration, doneCh <-chan struct{}) error {
+	if interval < 0 {
+		return ErrInvalidArgument("Health check interval cannot be negative.")
+	}
+	if interval == 0 {
+		interval = defaultHealthCheckInterval
+	}
+	if !atomic.CompareAndSwapInt32(c.healthStatus, healthUnknown, healthOnline) {
+		return ErrInvalidArgument("Health check is already running.")
+	}
+
+	go func() {
+		defer atomic.StoreInt32(c.healthStatus, healthUnknown)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			c.setHealth(c.probeHealth(interval))
+			select {
+			case <-doneCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// IsOnline returns true unless a running health check has
+// found the endpoint unreachable.
+func (c Client) IsOnline() bool {
+	return !c.IsOffline()
+}
+
+// IsOffline returns true if a running health check has found
+// the endpoint unreachable.
+func (c Client) IsOffline() bool {
+	return atomic.LoadInt32(c.healthStatus) == healthOffline
+}
+
+// setHealth records the probe result, unless the health
+// check was stopped meanwhile.
+func (c Client) setHealth(online bool) {
+	status := healthOffline
+	if online {
+		status = healthOnline
+	}
+	for {
+		current := atomic.LoadInt32(c.healthStatus)
+		if current == healthUnknown || current == status {
+			return
+		}
+		if atomic.CompareAndSwapInt32(c.healthStatus, current, status) {
+			return
+		}
+	}
+}
+
+// probeHealth issues a single readiness probe bounded by timeout.
+// Any response other than a server error means the endpoint is
+// reachable and serving requests.
+func (c Client) probeHealth(timeout time.Duration) bool {
+	targetURL := c.endpointURL
+	targetURL.Path = healthCheckPath
+
+	req, err := http.NewRequest("GET", targetURL.String(), nil)
+	if err != nil {
+		return false
+	}
+	// Cancel the probe if it takes longer than timeout.
+	cancelCh := make(chan struct{})
+	req.Cancel = cancelCh
+	timer := time.AfterFunc(timeout, func() { close(cancelCh) })
+	defer timer.Stop()
+
+	resp, err := c.do(req)
+	defer closeResponse(resp)
+	if err != nil {
+		return false
+	}
+	return resp.StatusCode < http.StatusInternalServerError
+}
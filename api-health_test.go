@@ -0,0 +1,85 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests a single health probe against ready and unready servers.
+func TestProbeHealth(t *testing.T) {
+	testCases := []struct {
+		status int
+		online bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusForbidden, true},
+		{http.StatusNotFound, true},
+		{http.StatusServiceUnavailable, false},
+	}
+	for i, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != healthCheckPath {
+				t.Errorf("Test %d: unexpected probe path %s", i+1, r.URL.Path)
+			}
+			w.WriteHeader(testCase.status)
+		}))
+		clnt, err := New(server.Listener.Addr().String(), "", "", false)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		if online := clnt.probeHealth(time.Second); online != testCase.online {
+			t.Errorf("Test %d: expected online %t, got %t", i+1, testCase.online, online)
+		}
+		server.Close()
+	}
+}
+
+// Tests health check transitions once the endpoint goes away.
+func TestHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	clnt, err := New(server.Listener.Addr().String(), "", "", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if !clnt.IsOnline() {
+		t.Fatal("Error: client must be online before health check starts")
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	if err = clnt.HealthCheck(10*time.Millisecond, doneCh); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.HealthCheck(10*time.Millisecond, doneCh); err == nil {
+		t.Fatal("Error: second health check must fail")
+	}
+
+	server.Close()
+	for i := 0; i < 200 && clnt.IsOnline(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !clnt.IsOffline() {
+		t.Fatal("Error: client must be offline after endpoint is closed")
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import "io"
+
+// API is the set of bucket and object operations implemented by
+// Client. Applications can accept an API instead of a *Client to
+// swap in alternate implementations, such as the in-memory one
+// provided by package objectstoragetest, in their tests.
+type API interface {
+	// Bucket operations.
+	MakeBucket(bucketName string, location string) error
+	ListBuckets() ([]BucketInfo, error)
+	BucketExists(bucketName string) (bool, error)
+	RemoveBucket(bucketName string) error
+	ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan ObjectInfo
+	ListObjectsV2(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan ObjectInfo
+
+	// Object operations.
+	GetObject(bucketName, objectName string) (*Object, error)
+	FGetObject(bucketName, objectName, filePath string) error
+	PutObject(bucketName, objectName string, reader io.Reader, contentType string) (n int64, err error)
+	PutObjectWithMetadata(bucketName, objectName string, reader io.Reader, metaData map[string][]string, progress io.Reader) (n int64, err error)
+	FPutObject(bucketName, objectName, filePath, contentType string) (n int64, err error)
+	StatObject(bucketName, objectName string) (ObjectInfo, error)
+	CopyObject(bucketName string, objectName string, objectSource string, cpCond CopyConditions) error
+	RemoveObject(bucketName, objectName string) error
+	RemoveObjects(bucketName string, objectsCh <-chan string) <-chan RemoveObjectError
+}
+
+// Client implements API.
+var _ API = Client{}
@@ -0,0 +1,144 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BucketSortKey - field buckets are sorted by when listing.
+type BucketSortKey string
+
+// Supported bucket sort keys.
+const (
+	// SortByName sorts buckets by name, the order the server lists
+	// them in.
+	SortByName BucketSortKey = "name"
+	// SortByCreationDate sorts buckets by creation date, oldest first.
+	SortByCreationDate BucketSortKey = "creationDate"
+)
+
+// ListBucketsOptions - filtering, ordering and region resolution of
+// ListBucketsWithOptions.
+type ListBucketsOptions struct {
+	// Prefix lists only buckets whose name starts with it.
+	Prefix string
+
+	// SortBy orders the buckets, by name when empty.
+	SortBy BucketSortKey
+	// Reverse inverts the sort order.
+	Reverse bool
+
+	// ResolveRegion sets the Region of every bucket listed.
+	ResolveRegion bool
+}
+
+// bucketsByName sorts buckets by name.
+type bucketsByName []BucketInfo
+
+func (b bucketsByName) Len() int           { return len(b) }
+func (b bucketsByName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b bucketsByName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+
+// bucketsByCreationDate sorts buckets by creation date, buckets
+// created at the same time by name.
+type bucketsByCreationDate []BucketInfo
+
+func (b bucketsByCreationDate) Len() int      { return len(b) }
+func (b bucketsByCreationDate) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b bucketsByCreationDate) Less(i, j int) bool {
+	if b[i].CreationDate.Equal(b[j].CreationDate) {
+		return b[i].Name < b[j].Name
+	}
+	return b[i].CreationDate.Before(b[j].CreationDate)
+}
+
+// ListBucketsWithOptions - lists buckets owned by the authenticated
+// user as ListBuckets does, keeping only buckets matching the prefix
+// in opts, in the requested order. Regions are looked up concurrently
+// when requested, the lookups are answered from the bucket location
+// cache whenever possible.
+func (c Client) ListBucketsWithOptions(opts ListBucketsOptions) ([]BucketInfo, error) {
+	switch opts.SortBy {
+	case "", SortByName, SortByCreationDate:
+	default:
+		return nil, ErrInvalidArgument("Unsupported bucket sort key " + string(opts.SortBy) + ".")
+	}
+
+	allBuckets, err := c.ListBuckets()
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := []BucketInfo{}
+	for _, bucket := range allBuckets {
+		if strings.HasPrefix(bucket.Name, opts.Prefix) {
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	var sorter sort.Interface
+	if opts.SortBy == SortByCreationDate {
+		sorter = bucketsByCreationDate(buckets)
+	} else {
+		sorter = bucketsByName(buckets)
+	}
+	if opts.Reverse {
+		sorter = sort.Reverse(sorter)
+	}
+	sort.Sort(sorter)
+
+	if opts.ResolveRegion {
+		if err = c.resolveBucketRegions(buckets); err != nil {
+			return nil, err
+		}
+	}
+	return buckets, nil
+}
+
+// resolveBucketRegions - sets the region of buckets, returns the first
+// error encountered.
+func (c Client) resolveBucketRegions(buckets []BucketInfo) error {
+	indexCh := make(chan int, len(buckets))
+	for i := range buckets {
+		indexCh <- i
+	}
+	close(indexCh)
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(buckets))
+	for w := 0; w < totalWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexCh {
+				region, err := c.getBucketLocation(buckets[i].Name)
+				if err != nil {
+					errCh <- err
+					return
+				}
+				buckets[i].Region = region
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	return <-errCh
+}
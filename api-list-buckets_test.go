@@ -0,0 +1,84 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Tests buckets are filtered, sorted and their regions resolved.
+func TestListBucketsWithOptions(t *testing.T) {
+	regions := map[string]string{
+		"/logs-a/": "eu-west-1",
+		"/logs-b/": "us-east-1",
+		"/data/":   "ap-south-1",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` + regions[r.URL.Path] + `</LocationConstraint>`))
+			return
+		}
+		w.Write([]byte(`<ListAllMyBucketsResult><Owner><ID>id</ID><DisplayName>owner</DisplayName></Owner><Buckets>` +
+			`<Bucket><Name>data</Name><CreationDate>2017-01-01T00:00:00.000Z</CreationDate></Bucket>` +
+			`<Bucket><Name>logs-a</Name><CreationDate>2017-03-01T00:00:00.000Z</CreationDate></Bucket>` +
+			`<Bucket><Name>logs-b</Name><CreationDate>2017-02-01T00:00:00.000Z</CreationDate></Bucket>` +
+			`</Buckets></ListAllMyBucketsResult>`))
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	testCases := []struct {
+		opts    ListBucketsOptions
+		names   string
+		regions string
+	}{
+		{ListBucketsOptions{}, "data,logs-a,logs-b", ",,"},
+		{ListBucketsOptions{Prefix: "logs-", Reverse: true}, "logs-b,logs-a", ","},
+		{ListBucketsOptions{SortBy: SortByCreationDate}, "data,logs-b,logs-a", ",,"},
+		{ListBucketsOptions{SortBy: SortByCreationDate, Reverse: true, ResolveRegion: true}, "logs-a,logs-b,data", "eu-west-1,us-east-1,ap-south-1"},
+		{ListBucketsOptions{Prefix: "none"}, "", ""},
+	}
+	for i, testCase := range testCases {
+		buckets, err := clnt.ListBucketsWithOptions(testCase.opts)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		var names, regions []string
+		for _, bucket := range buckets {
+			names = append(names, bucket.Name)
+			regions = append(regions, bucket.Region)
+		}
+		if got := strings.Join(names, ","); got != testCase.names {
+			t.Errorf("Test %d: expected buckets %s, got %s", i+1, testCase.names, got)
+		}
+		if got := strings.Join(regions, ","); got != testCase.regions {
+			t.Errorf("Test %d: expected regions %s, got %s", i+1, testCase.regions, got)
+		}
+	}
+
+	if _, err = clnt.ListBucketsWithOptions(ListBucketsOptions{SortBy: "size"}); err == nil {
+		t.Fatal("Expected unsupported sort key to fail")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// listingStream - decodes listing responses element by element,
+// passing their entries on as soon as they are decoded, so consumers
+// do not wait for the whole page and pages are not held in memory.
+type listingStream struct {
+	onObject func(ObjectInfo) error
+	onPrefix func(CommonPrefix) error
+
+	// Encoding type of the keys, known once a response sent its
+	// EncodingType or ended without one. Entries decoded before that
+	// are held back, as some servers send it after the entries.
+	encodingType  string
+	encodingKnown bool
+}
+
+// collectListing - returns a stream appending the entries of a
+// listing to contents and prefixes.
+func collectListing(contents *[]ObjectInfo, prefixes *[]CommonPrefix) *listingStream {
+	return &listingStream{
+		onObject: func(object ObjectInfo) error {
+			*contents = append(*contents, object)
+			return nil
+		},
+		onPrefix: func(prefix CommonPrefix) error {
+			*prefixes = append(*prefixes, prefix)
+			return nil
+		},
+	}
+}
+
+// decode - decodes the listing response in body. Contents and
+// CommonPrefixes are passed to the handlers of the stream, the other
+// child elements of the root are decoded into fields by name. An
+// error returned by a handler stops decoding and is returned.
+func (s *listingStream) decode(body io.Reader, fields map[string]interface{}) error {
+	d := xml.NewDecoder(body)
+
+	// Skip to the root element.
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			break
+		}
+	}
+
+	var pending []interface{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			// End of the root element, servers ignoring the encoding
+			// type do not send one.
+			s.encodingKnown = true
+			return s.emit(pending...)
+		case xml.StartElement:
+			var entry interface{}
+			switch t.Name.Local {
+			case "Contents":
+				entry = &ObjectInfo{}
+			case "CommonPrefixes":
+				entry = &CommonPrefix{}
+			case "EncodingType":
+				var encodingType string
+				if err = d.DecodeElement(&encodingType, &t); err != nil {
+					return err
+				}
+				if field, ok := fields["EncodingType"].(*string); ok {
+					*field = encodingType
+				}
+				if !s.encodingKnown {
+					s.encodingType, s.encodingKnown = encodingType, true
+					if err = s.emit(pending...); err != nil {
+						return err
+					}
+					pending = nil
+				}
+				continue
+			default:
+				if field, ok := fields[t.Name.Local]; ok {
+					err = d.DecodeElement(field, &t)
+				} else {
+					err = d.Skip()
+				}
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if err = d.DecodeElement(entry, &t); err != nil {
+				return err
+			}
+			if !s.encodingKnown {
+				pending = append(pending, entry)
+				continue
+			}
+			if err = s.emit(entry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// emit - passes decoded entries to the handlers of the stream.
+func (s *listingStream) emit(entries ...interface{}) error {
+	for _, entry := range entries {
+		var err error
+		switch entry := entry.(type) {
+		case *ObjectInfo:
+			if err = decodeListingKeys(s.encodingType, &entry.Key); err == nil {
+				err = s.onObject(*entry)
+			}
+		case *CommonPrefix:
+			if err = decodeListingKeys(s.encodingType, &entry.Prefix); err == nil {
+				err = s.onPrefix(*entry)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests listing entries are passed on as they are decoded.
+func TestListingStreamDecode(t *testing.T) {
+	reader, writer := io.Pipe()
+	objectCh := make(chan ObjectInfo)
+	stream := &listingStream{
+		onObject: func(object ObjectInfo) error {
+			objectCh <- object
+			return nil
+		},
+		onPrefix: func(prefix CommonPrefix) error {
+			objectCh <- ObjectInfo{Key: prefix.Prefix}
+			return nil
+		},
+	}
+	var isTruncated bool
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- stream.decode(reader, map[string]interface{}{"IsTruncated": &isTruncated})
+	}()
+
+	writer.Write([]byte(`<ListBucketResult><EncodingType>url</EncodingType><Contents><Key>a%2Fb</Key><Size>1</Size></Contents>`))
+	select {
+	case object := <-objectCh:
+		if object.Key != "a/b" || object.Size != 1 {
+			t.Fatalf("Unexpected object %+v", object)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Object was not passed on before the end of the response")
+	}
+	go func() {
+		writer.Write([]byte(`<KeyCount>2</KeyCount><CommonPrefixes><Prefix>c%25</Prefix></CommonPrefixes><IsTruncated>true</IsTruncated></ListBucketResult>`))
+		writer.Close()
+	}()
+	if object := <-objectCh; object.Key != "c%" {
+		t.Fatalf("Unexpected prefix %s", object.Key)
+	}
+	if err := <-errCh; err != nil || !isTruncated {
+		t.Fatalf("Expected truncated response, got %v, %v", isTruncated, err)
+	}
+}
+
+// Tests entries are held back while the encoding type is unknown.
+func TestListingStreamEncodingType(t *testing.T) {
+	var contents []ObjectInfo
+	var prefixes []CommonPrefix
+	stream := collectListing(&contents, &prefixes)
+
+	testCases := []struct {
+		body     string
+		expected string
+	}{
+		// Encoding type sent after the entries.
+		{`<ListBucketResult><Contents><Key>a%25</Key></Contents><EncodingType>url</EncodingType></ListBucketResult>`, "a%"},
+		// Known from the previous response.
+		{`<ListBucketResult><Contents><Key>b%25</Key></Contents></ListBucketResult>`, "b%"},
+	}
+	for i, testCase := range testCases {
+		contents = nil
+		if err := stream.decode(strings.NewReader(testCase.body), nil); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if len(contents) != 1 || contents[0].Key != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %v", i+1, testCase.expected, contents)
+		}
+	}
+
+	// Servers ignoring the encoding type send keys as is.
+	contents = nil
+	stream = collectListing(&contents, &prefixes)
+	if err := stream.decode(strings.NewReader(`<ListBucketResult><Contents><Key>a%25</Key></Contents></ListBucketResult>`), nil); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(contents) != 1 || contents[0].Key != "a%25" {
+		t.Errorf("Expected key as is, got %v", contents)
+	}
+
+	// Handler errors stop decoding.
+	errStop := errors.New("stop")
+	stream.onObject = func(ObjectInfo) error { return errStop }
+	if err := stream.decode(strings.NewReader(`<ListBucketResult><Contents><Key>a</Key></Contents><Contents><Key>b</Key></Contents></ListBucketResult>`), nil); err != errStop {
+		t.Errorf("Expected the handler error, got %v", err)
+	}
+	if err := stream.decode(strings.NewReader(`<ListBucketResult><Contents><Key>a</Key>`), nil); err == nil {
+		t.Error("Expected truncated response to fail")
+	}
+}
+
+// Tests listed objects are received before the page is complete.
+func TestListObjectsStreaming(t *testing.T) {
+	receivedCh := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		fmt.Fprint(w, `<ListBucketResult><EncodingType>url</EncodingType><IsTruncated>false</IsTruncated>`)
+		fmt.Fprint(w, `<Contents><Key>object-0</Key><Size>1</Size></Contents>`)
+		w.(http.Flusher).Flush()
+		select {
+		case <-receivedCh:
+		case <-time.After(5 * time.Second):
+		}
+		fmt.Fprint(w, `<Contents><Key>object-1</Key><Size>1</Size></Contents></ListBucketResult>`)
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	for _, useV1 := range []bool{false, true} {
+		receivedCh = make(chan struct{})
+		start := time.Now()
+		var keys []string
+		for object := range clnt.ListObjectsWithOptions("bucket", ListObjectsOptions{Recursive: true, UseV1: useV1}, nil) {
+			if object.Err != nil {
+				t.Fatal("Error:", object.Err)
+			}
+			if len(keys) == 0 {
+				close(receivedCh)
+			}
+			keys = append(keys, object.Key)
+		}
+		if strings.Join(keys, ",") != "object-0,object-1" {
+			t.Errorf("Unexpected objects %v", keys)
+		}
+		if time.Since(start) > 4*time.Second {
+			t.Errorf("UseV1 %v: first object was not received before the page was complete", useV1)
+		}
+	}
+}
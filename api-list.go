@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
+	"regexp"
 	"strings"
 
 	"github.com/minio/minio-go/pkg/s3utils"
@@ -58,6 +60,213 @@ func (c Client) ListBuckets() ([]BucketInfo, error) {
 
 /// Bucket Read Operations.
 
+// ListObjectsOptions - options of ListObjectsWithOptions.
+type ListObjectsOptions struct {
+	// Prefix lists only objects whose name starts with it.
+	Prefix string
+	// Recursive lists all objects instead of grouping names at "/".
+	Recursive bool
+
+	// MaxResults stops listing once as many objects and common
+	// prefixes were sent, no further pages are requested. All
+	// objects are listed when zero.
+	MaxResults int
+
+	// UseV1 lists with List Objects instead of List Objects V2, for
+	// servers which do not support the latter.
+	UseV1 bool
+
+	// BufferSize is the capacity of the returned channel, up to as
+	// many entries are listed ahead of the caller. Defaults to 1.
+	BufferSize int
+	// Prefetch requests the next page while the entries of the
+	// current page are consumed.
+	Prefetch bool
+
+	// Match lists only objects whose name matches the glob pattern,
+	// as understood by path.Match. Patterns without a "/" are matched
+	// against the last element of the name, so "*.log" matches
+	// "logs/app.log". Common prefixes are not filtered.
+	Match string
+	// MatchRegexp lists only objects whose name matches it, in
+	// addition to Match.
+	MatchRegexp *regexp.Regexp
+}
+
+// matches - reports whether the object name is listed with opts.
+func (opts ListObjectsOptions) matches(objectName string) bool {
+	if opts.Match != "" {
+		name := objectName
+		if !strings.Contains(opts.Match, "/") {
+			name = path.Base(objectName)
+		}
+		if ok, _ := path.Match(opts.Match, name); !ok {
+			return false
+		}
+	}
+	return opts.MatchRegexp == nil || opts.MatchRegexp.MatchString(objectName)
+}
+
+// listingPage - entries of a listing page, and the error listing it.
+type listingPage struct {
+	objects []ObjectInfo
+	err     error
+}
+
+// errListingStopped - returned once the caller closed doneCh while
+// entries were sent.
+var errListingStopped = errors.New("Listing stopped by the caller")
+
+// streamListing - sends the entries of the pages listed by nextPage
+// over the returned channel until nextPage reports there are no more
+// pages, fails or the caller closes doneCh. nextPage passes each entry
+// to emit as soon as it is decoded, and stops with the error emit
+// returns. With opts.Prefetch pages are requested by a separate
+// goroutine running a page ahead, and sent once complete.
+func (c Client) streamListing(bucketName string, opts ListObjectsOptions, doneCh <-chan struct{}, nextPage func(emit func(ObjectInfo) error) (bool, error)) <-chan ObjectInfo {
+	bufferSize := opts.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	// Allocate new list objects channel.
+	objectStatCh := make(chan ObjectInfo, bufferSize)
+
+	// Validate bucket name.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		defer close(objectStatCh)
+		objectStatCh <- ObjectInfo{
+			Err: err,
+		}
+		return objectStatCh
+	}
+	// Validate incoming object prefix.
+	if err := s3utils.CheckValidObjectNamePrefix(opts.Prefix); err != nil {
+		defer close(objectStatCh)
+		objectStatCh <- ObjectInfo{
+			Err: err,
+		}
+		return objectStatCh
+	}
+
+	send := func(object ObjectInfo) error {
+		select {
+		// Send object content.
+		case objectStatCh <- object:
+			return nil
+		// If receives done from the caller, return here.
+		case <-doneCh:
+			return errListingStopped
+		}
+	}
+
+	// Pages are requested by the sending goroutine unless prefetched.
+	pageCh := make(chan listingPage)
+	stopCh := make(chan struct{})
+	fetchPages := func() {
+		defer close(pageCh)
+		for {
+			var page listingPage
+			var more bool
+			more, page.err = nextPage(func(object ObjectInfo) error {
+				page.objects = append(page.objects, object)
+				return nil
+			})
+			select {
+			case pageCh <- page:
+			case <-stopCh:
+				return
+			}
+			if page.err != nil || !more {
+				return
+			}
+		}
+	}
+
+	// Initiate list objects goroutine here.
+	go func(objectStatCh chan<- ObjectInfo) {
+		defer close(objectStatCh)
+		defer close(stopCh)
+		if !opts.Prefetch {
+			for {
+				more, err := nextPage(send)
+				if err == errListingStopped {
+					return
+				}
+				if err != nil {
+					objectStatCh <- ObjectInfo{
+						Err: err,
+					}
+					return
+				}
+				if !more {
+					return
+				}
+			}
+		}
+
+		go fetchPages()
+		for page := range pageCh {
+			for _, object := range page.objects {
+				if send(object) != nil {
+					return
+				}
+			}
+			if page.err != nil {
+				objectStatCh <- ObjectInfo{
+					Err: page.err,
+				}
+				return
+			}
+		}
+	}(objectStatCh)
+	return objectStatCh
+}
+
+// pageSize - returns the max-keys to request when remaining results
+// are left to be sent, remaining is zero for unlimited listings.
+func pageSize(remaining int) int {
+	if remaining <= 0 || remaining > 1000 {
+		return 1000
+	}
+	return remaining
+}
+
+// ListObjectsWithOptions - lists objects of bucketName as specified in
+// opts, the listing is closed after the last object, after MaxResults
+// objects or when the caller closes doneCh.
+//
+//   api := client.New(....)
+//   doneCh := make(chan struct{})
+//   defer close(doneCh)
+//   // List the first 50 objects under 'photos/'.
+//   opts := minio.ListObjectsOptions{Prefix: "photos/", Recursive: true, MaxResults: 50}
+//   for message := range api.ListObjectsWithOptions("mytestbucket", opts, doneCh) {
+//       fmt.Println(message)
+//   }
+//
+func (c Client) ListObjectsWithOptions(bucketName string, opts ListObjectsOptions, doneCh <-chan struct{}) <-chan ObjectInfo {
+	if opts.MaxResults < 0 {
+		objectStatCh := make(chan ObjectInfo, 1)
+		defer close(objectStatCh)
+		objectStatCh <- ObjectInfo{
+			Err: ErrInvalidArgument("Maximum number of results cannot be negative."),
+		}
+		return objectStatCh
+	}
+	if _, err := path.Match(opts.Match, ""); err != nil {
+		objectStatCh := make(chan ObjectInfo, 1)
+		defer close(objectStatCh)
+		objectStatCh <- ObjectInfo{
+			Err: ErrInvalidArgument("Invalid match pattern " + opts.Match + "."),
+		}
+		return objectStatCh
+	}
+	if opts.UseV1 {
+		return c.listObjects(bucketName, opts, doneCh)
+	}
+	return c.listObjectsV2(bucketName, opts, doneCh)
+}
+
 // ListObjectsV2 lists all objects matching the objectPrefix from
 // the specified bucket. If recursion is enabled it would list
 // all subdirectories and all its contents.
@@ -79,11 +288,14 @@ func (c Client) ListBuckets() ([]BucketInfo, error) {
 //   }
 //
 func (c Client) ListObjectsV2(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan ObjectInfo {
-	// Allocate new list objects channel.
-	objectStatCh := make(chan ObjectInfo, 1)
+	return c.listObjectsV2(bucketName, ListObjectsOptions{Prefix: objectPrefix, Recursive: recursive}, doneCh)
+}
+
+// listObjectsV2 - lists objects with List Objects V2 as specified in opts.
+func (c Client) listObjectsV2(bucketName string, opts ListObjectsOptions, doneCh <-chan struct{}) <-chan ObjectInfo {
 	// Default listing is delimited at "/"
 	delimiter := "/"
-	if recursive {
+	if opts.Recursive {
 		// If recursive we do not delimit.
 		delimiter = ""
 	}
@@ -91,77 +303,63 @@ func (c Client) ListObjectsV2(bucketName, objectPrefix string, recursive bool, d
 	// Return object owner information by default
 	fetchOwner := true
 
-	// Validate bucket name.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
-		defer close(objectStatCh)
-		objectStatCh <- ObjectInfo{
-			Err: err,
+	// Save continuationToken for next request.
+	var continuationToken string
+	// Entries left to send when the number of results is limited.
+	remaining := opts.MaxResults
+
+	// Remembers across pages whether keys are url encoded.
+	stream := &listingStream{}
+
+	return c.streamListing(bucketName, opts, doneCh, func(emit func(ObjectInfo) error) (bool, error) {
+		// Objects are sent as they are decoded, common prefixes
+		// after them.
+		var sent int
+		var prefixes []CommonPrefix
+		stream.onObject = func(object ObjectInfo) error {
+			if !opts.matches(object.Key) {
+				return nil
+			}
+			sent++
+			return emit(object)
 		}
-		return objectStatCh
-	}
-
-	// Validate incoming object prefix.
-	if err := s3utils.CheckValidObjectNamePrefix(objectPrefix); err != nil {
-		defer close(objectStatCh)
-		objectStatCh <- ObjectInfo{
-			Err: err,
+		stream.onPrefix = func(prefix CommonPrefix) error {
+			prefixes = append(prefixes, prefix)
+			return nil
 		}
-		return objectStatCh
-	}
-
-	// Initiate list objects goroutine here.
-	go func(objectStatCh chan<- ObjectInfo) {
-		defer close(objectStatCh)
-		// Save continuationToken for next request.
-		var continuationToken string
-		for {
-			// Get list of objects a maximum of 1000 per request.
-			result, err := c.listObjectsV2Query(bucketName, objectPrefix, continuationToken, fetchOwner, delimiter, 1000)
-			if err != nil {
-				objectStatCh <- ObjectInfo{
-					Err: err,
-				}
-				return
-			}
 
-			// If contents are available loop through and send over channel.
-			for _, object := range result.Contents {
-				select {
-				// Send object content.
-				case objectStatCh <- object:
-				// If receives done from the caller, return here.
-				case <-doneCh:
-					return
-				}
-			}
+		// Get list of objects a maximum of 1000 per request, and
+		// no more than the remaining results.
+		result, err := c.listObjectsV2Stream(bucketName, opts.Prefix, continuationToken, fetchOwner, delimiter, pageSize(remaining), stream)
+		if err != nil {
+			return false, err
+		}
 
-			// Send all common prefixes if any.
-			// NOTE: prefixes are only present if the request is delimited.
-			for _, obj := range result.CommonPrefixes {
-				select {
-				// Send object prefixes.
-				case objectStatCh <- ObjectInfo{
-					Key:  obj.Prefix,
-					Size: 0,
-				}:
-				// If receives done from the caller, return here.
-				case <-doneCh:
-					return
-				}
+		// Add all common prefixes if any.
+		// NOTE: prefixes are only present if the request is delimited.
+		for _, obj := range prefixes {
+			sent++
+			if err = emit(ObjectInfo{Key: obj.Prefix, Size: 0}); err != nil {
+				return false, err
 			}
+		}
 
-			// If continuation token present, save it for next request.
-			if result.NextContinuationToken != "" {
-				continuationToken = result.NextContinuationToken
-			}
+		// If continuation token present, save it for next request.
+		if result.NextContinuationToken != "" {
+			continuationToken = result.NextContinuationToken
+		}
 
-			// Listing ends result is not truncated, return right here.
-			if !result.IsTruncated {
-				return
+		// Listing ends once enough results were sent.
+		if opts.MaxResults > 0 {
+			remaining -= sent
+			if remaining <= 0 {
+				return false, nil
 			}
 		}
-	}(objectStatCh)
-	return objectStatCh
+
+		// Listing ends result is not truncated.
+		return result.IsTruncated, nil
+	})
 }
 
 // listObjectsV2Query - (List Objects V2) - List some or all (up to 1000) of the objects in a bucket.
@@ -174,6 +372,18 @@ func (c Client) ListObjectsV2(bucketName, objectPrefix string, recursive bool, d
 // ?prefix - Limits the response to keys that begin with the specified prefix.
 // ?max-keys - Sets the maximum number of keys returned in the response body.
 func (c Client) listObjectsV2Query(bucketName, objectPrefix, continuationToken string, fetchOwner bool, delimiter string, maxkeys int) (ListBucketV2Result, error) {
+	var contents []ObjectInfo
+	var prefixes []CommonPrefix
+	listBucketResult, err := c.listObjectsV2Stream(bucketName, objectPrefix, continuationToken, fetchOwner, delimiter, maxkeys, collectListing(&contents, &prefixes))
+	listBucketResult.Contents = contents
+	listBucketResult.CommonPrefixes = prefixes
+	return listBucketResult, err
+}
+
+// listObjectsV2Stream - lists objects like listObjectsV2Query, passing
+// contents and common prefixes to stream as they are decoded instead
+// of returning them.
+func (c Client) listObjectsV2Stream(bucketName, objectPrefix, continuationToken string, fetchOwner bool, delimiter string, maxkeys int, stream *listingStream) (ListBucketV2Result, error) {
 	// Validate bucket name.
 	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
 		return ListBucketV2Result{}, err
@@ -189,6 +399,10 @@ func (c Client) listObjectsV2Query(bucketName, objectPrefix, continuationToken s
 	// Always set list-type in ListObjects V2
 	urlValues.Set("list-type", "2")
 
+	// Have keys url encoded, so that keys with characters invalid
+	// in XML survive the response.
+	urlValues.Set("encoding-type", "url")
+
 	// Set object prefix.
 	if objectPrefix != "" {
 		urlValues.Set("prefix", objectPrefix)
@@ -232,7 +446,22 @@ func (c Client) listObjectsV2Query(bucketName, objectPrefix, continuationToken s
 
 	// Decode listBuckets XML.
 	listBucketResult := ListBucketV2Result{}
-	if err = xmlDecoder(resp.Body, &listBucketResult); err != nil {
+	err = stream.decode(resp.Body, map[string]interface{}{
+		"Delimiter":             &listBucketResult.Delimiter,
+		"EncodingType":          &listBucketResult.EncodingType,
+		"IsTruncated":           &listBucketResult.IsTruncated,
+		"MaxKeys":               &listBucketResult.MaxKeys,
+		"Name":                  &listBucketResult.Name,
+		"NextContinuationToken": &listBucketResult.NextContinuationToken,
+		"ContinuationToken":     &listBucketResult.ContinuationToken,
+		"Prefix":                &listBucketResult.Prefix,
+		"FetchOwner":            &listBucketResult.FetchOwner,
+		"StartAfter":            &listBucketResult.StartAfter,
+	})
+	if err != nil {
+		return listBucketResult, err
+	}
+	if err = decodeListingKeys(listBucketResult.EncodingType, &listBucketResult.Prefix, &listBucketResult.Delimiter, &listBucketResult.StartAfter); err != nil {
 		return listBucketResult, err
 	}
 
@@ -269,86 +498,81 @@ func (c Client) listObjectsV2Query(bucketName, objectPrefix, continuationToken s
 //   }
 //
 func (c Client) ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan ObjectInfo {
-	// Allocate new list objects channel.
-	objectStatCh := make(chan ObjectInfo, 1)
+	return c.listObjects(bucketName, ListObjectsOptions{Prefix: objectPrefix, Recursive: recursive}, doneCh)
+}
+
+// listObjects - lists objects with List Objects as specified in opts.
+func (c Client) listObjects(bucketName string, opts ListObjectsOptions, doneCh <-chan struct{}) <-chan ObjectInfo {
 	// Default listing is delimited at "/"
 	delimiter := "/"
-	if recursive {
+	if opts.Recursive {
 		// If recursive we do not delimit.
 		delimiter = ""
 	}
-	// Validate bucket name.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
-		defer close(objectStatCh)
-		objectStatCh <- ObjectInfo{
-			Err: err,
-		}
-		return objectStatCh
-	}
-	// Validate incoming object prefix.
-	if err := s3utils.CheckValidObjectNamePrefix(objectPrefix); err != nil {
-		defer close(objectStatCh)
-		objectStatCh <- ObjectInfo{
-			Err: err,
-		}
-		return objectStatCh
-	}
 
-	// Initiate list objects goroutine here.
-	go func(objectStatCh chan<- ObjectInfo) {
-		defer close(objectStatCh)
-		// Save marker for next request.
-		var marker string
-		for {
-			// Get list of objects a maximum of 1000 per request.
-			result, err := c.listObjectsQuery(bucketName, objectPrefix, marker, delimiter, 1000)
-			if err != nil {
-				objectStatCh <- ObjectInfo{
-					Err: err,
-				}
-				return
+	// Save marker for next request.
+	var marker string
+	// Entries left to send when the number of results is limited.
+	remaining := opts.MaxResults
+
+	// Remembers across pages whether keys are url encoded.
+	stream := &listingStream{}
+
+	return c.streamListing(bucketName, opts, doneCh, func(emit func(ObjectInfo) error) (bool, error) {
+		// Objects are sent as they are decoded, common prefixes
+		// after them.
+		var sent int
+		var lastKey string
+		var prefixes []CommonPrefix
+		stream.onObject = func(object ObjectInfo) error {
+			lastKey = object.Key
+			if !opts.matches(object.Key) {
+				return nil
 			}
+			sent++
+			return emit(object)
+		}
+		stream.onPrefix = func(prefix CommonPrefix) error {
+			prefixes = append(prefixes, prefix)
+			return nil
+		}
 
-			// If contents are available loop through and send over channel.
-			for _, object := range result.Contents {
-				// Save the marker.
-				marker = object.Key
-				select {
-				// Send object content.
-				case objectStatCh <- object:
-				// If receives done from the caller, return here.
-				case <-doneCh:
-					return
-				}
-			}
+		// Get list of objects a maximum of 1000 per request, and
+		// no more than the remaining results.
+		result, err := c.listObjectsStream(bucketName, opts.Prefix, marker, delimiter, pageSize(remaining), stream)
+		if err != nil {
+			return false, err
+		}
 
-			// Send all common prefixes if any.
-			// NOTE: prefixes are only present if the request is delimited.
-			for _, obj := range result.CommonPrefixes {
-				object := ObjectInfo{}
-				object.Key = obj.Prefix
-				object.Size = 0
-				select {
-				// Send object prefixes.
-				case objectStatCh <- object:
-				// If receives done from the caller, return here.
-				case <-doneCh:
-					return
-				}
+		// Save the marker.
+		if lastKey != "" {
+			marker = lastKey
+		}
+		// Add all common prefixes if any.
+		// NOTE: prefixes are only present if the request is delimited.
+		for _, obj := range prefixes {
+			sent++
+			if err = emit(ObjectInfo{Key: obj.Prefix, Size: 0}); err != nil {
+				return false, err
 			}
+		}
 
-			// If next marker present, save it for next request.
-			if result.NextMarker != "" {
-				marker = result.NextMarker
-			}
+		// If next marker present, save it for next request.
+		if result.NextMarker != "" {
+			marker = result.NextMarker
+		}
 
-			// Listing ends result is not truncated, return right here.
-			if !result.IsTruncated {
-				return
+		// Listing ends once enough results were sent.
+		if opts.MaxResults > 0 {
+			remaining -= sent
+			if remaining <= 0 {
+				return false, nil
 			}
 		}
-	}(objectStatCh)
-	return objectStatCh
+
+		// Listing ends result is not truncated.
+		return result.IsTruncated, nil
+	})
 }
 
 // listObjects - (List Objects) - List some or all (up to 1000) of the objects in a bucket.
@@ -361,6 +585,18 @@ func (c Client) ListObjects(bucketName, objectPrefix string, recursive bool, don
 // ?prefix - Limits the response to keys that begin with the specified prefix.
 // ?max-keys - Sets the maximum number of keys returned in the response body.
 func (c Client) listObjectsQuery(bucketName, objectPrefix, objectMarker, delimiter string, maxkeys int) (ListBucketResult, error) {
+	var contents []ObjectInfo
+	var prefixes []CommonPrefix
+	listBucketResult, err := c.listObjectsStream(bucketName, objectPrefix, objectMarker, delimiter, maxkeys, collectListing(&contents, &prefixes))
+	listBucketResult.Contents = contents
+	listBucketResult.CommonPrefixes = prefixes
+	return listBucketResult, err
+}
+
+// listObjectsStream - lists objects like listObjectsQuery, passing
+// contents and common prefixes to stream as they are decoded instead
+// of returning them.
+func (c Client) listObjectsStream(bucketName, objectPrefix, objectMarker, delimiter string, maxkeys int, stream *listingStream) (ListBucketResult, error) {
 	// Validate bucket name.
 	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
 		return ListBucketResult{}, err
@@ -384,6 +620,9 @@ func (c Client) listObjectsQuery(bucketName, objectPrefix, objectMarker, delimit
 	if delimiter != "" {
 		urlValues.Set("delimiter", delimiter)
 	}
+	// Have keys url encoded, so that keys with characters invalid
+	// in XML survive the response.
+	urlValues.Set("encoding-type", "url")
 
 	// maxkeys should default to 1000 or less.
 	if maxkeys == 0 || maxkeys > 1000 {
@@ -409,13 +648,42 @@ func (c Client) listObjectsQuery(bucketName, objectPrefix, objectMarker, delimit
 	}
 	// Decode listBuckets XML.
 	listBucketResult := ListBucketResult{}
-	err = xmlDecoder(resp.Body, &listBucketResult)
+	err = stream.decode(resp.Body, map[string]interface{}{
+		"Delimiter":    &listBucketResult.Delimiter,
+		"EncodingType": &listBucketResult.EncodingType,
+		"IsTruncated":  &listBucketResult.IsTruncated,
+		"Marker":       &listBucketResult.Marker,
+		"MaxKeys":      &listBucketResult.MaxKeys,
+		"Name":         &listBucketResult.Name,
+		"NextMarker":   &listBucketResult.NextMarker,
+		"Prefix":       &listBucketResult.Prefix,
+	})
 	if err != nil {
 		return listBucketResult, err
 	}
+	if err = decodeListingKeys(listBucketResult.EncodingType, &listBucketResult.Prefix, &listBucketResult.Delimiter, &listBucketResult.Marker, &listBucketResult.NextMarker); err != nil {
+		return listBucketResult, err
+	}
 	return listBucketResult, nil
 }
 
+// decodeListingKeys - decodes in place the keys of a listing response
+// sent with encoding-type=url. Servers ignoring the encoding type send
+// keys as is, which are left alone.
+func decodeListingKeys(encodingType string, keys ...*string) error {
+	if encodingType != "url" {
+		return nil
+	}
+	for _, key := range keys {
+		decoded, err := url.QueryUnescape(*key)
+		if err != nil {
+			return err
+		}
+		*key = decoded
+	}
+	return nil
+}
+
 // ListIncompleteUploads - List incompletely uploaded multipart objects.
 //
 // ListIncompleteUploads lists all incompleted objects matching the
@@ -563,6 +831,9 @@ func (c Client) listMultipartUploadsQuery(bucketName, keyMarker, uploadIDMarker,
 	if delimiter != "" {
 		urlValues.Set("delimiter", delimiter)
 	}
+	// Have keys url encoded, so that keys with characters invalid
+	// in XML survive the response.
+	urlValues.Set("encoding-type", "url")
 
 	// maxUploads should be 1000 or less.
 	if maxUploads == 0 || maxUploads > 1000 {
@@ -592,6 +863,17 @@ func (c Client) listMultipartUploadsQuery(bucketName, keyMarker, uploadIDMarker,
 	if err != nil {
 		return listMultipartUploadsResult, err
 	}
+	keys := []*string{&listMultipartUploadsResult.Prefix, &listMultipartUploadsResult.Delimiter,
+		&listMultipartUploadsResult.KeyMarker, &listMultipartUploadsResult.NextKeyMarker}
+	for i := range listMultipartUploadsResult.Uploads {
+		keys = append(keys, &listMultipartUploadsResult.Uploads[i].Key)
+	}
+	for i := range listMultipartUploadsResult.CommonPrefixes {
+		keys = append(keys, &listMultipartUploadsResult.CommonPrefixes[i].Prefix)
+	}
+	if err = decodeListingKeys(listMultipartUploadsResult.EncodingType, keys...); err != nil {
+		return listMultipartUploadsResult, err
+	}
 	return listMultipartUploadsResult, nil
 }
 
@@ -0,0 +1,239 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// listingRequests - max-keys of the listing requests received.
+type listingRequests struct {
+	sync.Mutex
+	maxKeys []int
+}
+
+func (l *listingRequests) add(maxKeys int) {
+	l.Lock()
+	defer l.Unlock()
+	l.maxKeys = append(l.maxKeys, maxKeys)
+}
+
+func (l *listingRequests) reset() {
+	l.Lock()
+	defer l.Unlock()
+	l.maxKeys = nil
+}
+
+func (l *listingRequests) String() string {
+	l.Lock()
+	defer l.Unlock()
+	return fmt.Sprint(l.maxKeys)
+}
+
+// newListingServer - returns a server listing totalObjects objects
+// with both List Objects versions, recording the max-keys requested.
+func newListingServer(totalObjects int, requests *listingRequests) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if _, ok := query["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		keys, _ := strconv.Atoi(query.Get("max-keys"))
+		requests.add(keys)
+
+		start := 0
+		marker := query.Get("continuation-token")
+		if query.Get("list-type") != "2" {
+			marker = query.Get("marker")
+		}
+		if marker != "" {
+			start, _ = strconv.Atoi(strings.TrimPrefix(marker, "object-"))
+			start++
+		}
+		end := start + keys
+		if end > totalObjects {
+			end = totalObjects
+		}
+
+		var contents, next string
+		for i := start; i < end; i++ {
+			contents += fmt.Sprintf("<Contents><Key>object-%05d</Key><Size>1</Size></Contents>", i)
+			next = fmt.Sprintf("object-%05d", i)
+		}
+		truncated := end < totalObjects
+		if query.Get("list-type") == "2" {
+			fmt.Fprintf(w, "<ListBucketResult><IsTruncated>%v</IsTruncated><NextContinuationToken>%s</NextContinuationToken>%s</ListBucketResult>", truncated, next, contents)
+			return
+		}
+		fmt.Fprintf(w, "<ListBucketResult><IsTruncated>%v</IsTruncated>%s</ListBucketResult>", truncated, contents)
+	}))
+}
+
+// Tests listing stops requesting pages once enough results were sent.
+func TestListObjectsMaxResults(t *testing.T) {
+	requests := &listingRequests{}
+	server := newListingServer(2500, requests)
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	testCases := []struct {
+		opts    ListObjectsOptions
+		count   int
+		maxKeys string
+	}{
+		{ListObjectsOptions{Recursive: true, MaxResults: 50}, 50, "[50]"},
+		{ListObjectsOptions{Recursive: true, MaxResults: 1500}, 1500, "[1000 500]"},
+		{ListObjectsOptions{Recursive: true, MaxResults: 1500, UseV1: true}, 1500, "[1000 500]"},
+		{ListObjectsOptions{Recursive: true, MaxResults: 5000}, 2500, "[1000 1000 1000]"},
+		{ListObjectsOptions{Recursive: true}, 2500, "[1000 1000 1000]"},
+		{ListObjectsOptions{Recursive: true, MaxResults: 1500, Prefetch: true}, 1500, "[1000 500]"},
+		{ListObjectsOptions{Recursive: true, Prefetch: true, BufferSize: 100, UseV1: true}, 2500, "[1000 1000 1000]"},
+	}
+	for i, testCase := range testCases {
+		requests.reset()
+		doneCh := make(chan struct{})
+		count := 0
+		for object := range clnt.ListObjectsWithOptions("bucket", testCase.opts, doneCh) {
+			if object.Err != nil {
+				t.Fatalf("Test %d: %v", i+1, object.Err)
+			}
+			if object.Key != fmt.Sprintf("object-%05d", count) {
+				t.Fatalf("Test %d: unexpected object %s at %d", i+1, object.Key, count)
+			}
+			count++
+		}
+		close(doneCh)
+		if count != testCase.count {
+			t.Errorf("Test %d: expected %d objects, got %d", i+1, testCase.count, count)
+		}
+		if got := requests.String(); got != testCase.maxKeys {
+			t.Errorf("Test %d: expected requests for %s keys, got %s", i+1, testCase.maxKeys, got)
+		}
+	}
+
+	for object := range clnt.ListObjectsWithOptions("bucket", ListObjectsOptions{MaxResults: -1}, nil) {
+		if object.Err == nil {
+			t.Fatal("Expected negative maximum number of results to fail")
+		}
+	}
+}
+
+// Tests listed objects are filtered by glob patterns and regexps.
+func TestListObjectsMatch(t *testing.T) {
+	requests := &listingRequests{}
+	server := newListingServer(2500, requests)
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	testCases := []struct {
+		opts  ListObjectsOptions
+		count int
+		last  string
+	}{
+		{ListObjectsOptions{Recursive: true, Match: "object-0001?"}, 10, "object-00019"},
+		{ListObjectsOptions{Recursive: true, MatchRegexp: regexp.MustCompile("5$")}, 250, "object-02495"},
+		{ListObjectsOptions{Recursive: true, MatchRegexp: regexp.MustCompile("5$"), UseV1: true}, 250, "object-02495"},
+		{ListObjectsOptions{Recursive: true, Match: "*-01*", MatchRegexp: regexp.MustCompile("5$")}, 100, "object-01995"},
+		// Results are counted after filtering.
+		{ListObjectsOptions{Recursive: true, MatchRegexp: regexp.MustCompile("^object-021"), MaxResults: 5, UseV1: true}, 5, "object-02104"},
+	}
+	for i, testCase := range testCases {
+		count, last := 0, ""
+		for object := range clnt.ListObjectsWithOptions("bucket", testCase.opts, nil) {
+			if object.Err != nil {
+				t.Fatalf("Test %d: %v", i+1, object.Err)
+			}
+			count++
+			last = object.Key
+		}
+		if count != testCase.count || last != testCase.last {
+			t.Errorf("Test %d: expected %d objects up to %s, got %d up to %s", i+1, testCase.count, testCase.last, count, last)
+		}
+	}
+
+	for object := range clnt.ListObjectsWithOptions("bucket", ListObjectsOptions{Match: "[a"}, nil) {
+		if ToErrorResponse(object.Err).Code != "InvalidArgument" {
+			t.Fatalf("Expected invalid pattern to fail, got %v", object.Err)
+		}
+	}
+
+	// Patterns without a slash match the last element of names.
+	matchCases := []struct {
+		pattern    string
+		objectName string
+		matches    bool
+	}{
+		{"*.log", "app.log", true},
+		{"*.log", "logs/2017/app.log", true},
+		{"*.log", "logs/app.log.gz", false},
+		{"logs/*.log", "logs/app.log", true},
+		{"logs/*.log", "logs/2017/app.log", false},
+	}
+	for i, testCase := range matchCases {
+		if matches := (ListObjectsOptions{Match: testCase.pattern}).matches(testCase.objectName); matches != testCase.matches {
+			t.Errorf("Test %d: expected %s matching %s to be %v", i+1, testCase.pattern, testCase.objectName, testCase.matches)
+		}
+	}
+}
+
+// Tests listing channels are buffered and pages prefetched on request.
+func TestListObjectsPrefetch(t *testing.T) {
+	requests := &listingRequests{}
+	server := newListingServer(2500, requests)
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	objectCh := clnt.ListObjectsWithOptions("bucket", ListObjectsOptions{Recursive: true, BufferSize: 10, Prefetch: true}, doneCh)
+	if cap(objectCh) != 10 {
+		t.Fatalf("Expected a buffer of 10 entries, got %d", cap(objectCh))
+	}
+	if object := <-objectCh; object.Err != nil {
+		t.Fatal("Error:", object.Err)
+	}
+
+	// The second page is requested while the first is still unread.
+	for i := 0; requests.String() != "[1000 1000]"; i++ {
+		if i == 100 {
+			t.Fatalf("Expected the second page to be prefetched, got requests %s", requests)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// MoveObject - moves a source object to a new object in the destination
+// bucket, implemented as a server side copy followed by a delete of the
+// source object.
+//
+// The copy is conditioned on the ETag of the source object, so that
+// a concurrent overwrite of the source is never copied and then lost.
+// The source object is only removed after the destination object has
+// been verified against the source.
+func (c Client) MoveObject(srcBucketName, srcObjectName, dstBucketName, dstObjectName string) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(srcBucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(srcObjectName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidBucketName(dstBucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(dstObjectName); err != nil {
+		return err
+	}
+	if srcBucketName == dstBucketName && srcObjectName == dstObjectName {
+		return ErrInvalidArgument("Source and destination objects cannot be the same.")
+	}
+	if c.dryRun("move object %s/%s to %s/%s", srcBucketName, srcObjectName, dstBucketName, dstObjectName) {
+		return nil
+	}
+
+	// Gather the ETag and size of the source object.
+	srcInfo, err := c.StatObject(srcBucketName, srcObjectName)
+	if err != nil {
+		return err
+	}
+
+	// Copy only if the source has not changed since it was stat'ed.
+	cpCond := CopyConditions{}
+	if err = cpCond.SetMatchETag(srcInfo.ETag); err != nil {
+		return err
+	}
+	cpObjRes, err := c.copyObjectDo(dstBucketName, dstObjectName, srcBucketName+"/"+srcObjectName, cpCond)
+	if err != nil {
+		return err
+	}
+
+	// ETag of a multipart object is not the md5sum of its content, a
+	// copied object is thus expected to carry a different ETag. Verify
+	// the ETag only for objects which were not uploaded in parts.
+	if !isMultipartETag(srcInfo.ETag) && cpObjRes.ETag != srcInfo.ETag {
+		return ErrorResponse{
+			Code:       "BadDigest",
+			Message:    fmt.Sprintf("Copied object ETag ‘%s’ does not match the source object ETag ‘%s’.", cpObjRes.ETag, srcInfo.ETag),
+			BucketName: dstBucketName,
+			Key:        dstObjectName,
+		}
+	}
+
+	// Verify that the destination holds all the data of the source.
+	dstInfo, err := c.StatObject(dstBucketName, dstObjectName)
+	if err != nil {
+		return err
+	}
+	if dstInfo.Size != srcInfo.Size {
+		return ErrUnexpectedEOF(dstInfo.Size, srcInfo.Size, dstBucketName, dstObjectName)
+	}
+
+	// Copy is verified, safely remove the source object.
+	return c.RemoveObject(srcBucketName, srcObjectName)
+}
+
+// isMultipartETag - verifies if the ETag is of the form MD5SUM-N which
+// is returned for objects uploaded as multipart.
+func isMultipartETag(etag string) bool {
+	return strings.Contains(etag, "-")
+}
@@ -0,0 +1,106 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// GetObjectACL - gets the access control list of an object. The
+// canned ACL granting the same permissions is reported if any.
+func (c Client) GetObjectACL(bucketName, objectName string) (ObjectACL, error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return ObjectACL{}, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return ObjectACL{}, err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("acl", "")
+
+	// Execute GET on object to get its ACL.
+	resp, err := c.executeMethod("GET", requestMetadata{
+		bucketName:         bucketName,
+		objectName:         objectName,
+		queryValues:        urlValues,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return ObjectACL{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return ObjectACL{}, httpRespToErrorResponse(resp, bucketName, objectName)
+	}
+
+	policy := accessControlPolicy{}
+	if err = xmlDecoder(resp.Body, &policy); err != nil {
+		return ObjectACL{}, err
+	}
+	acl := ObjectACL{Grants: policy.AccessControlList.Grants}
+	acl.Owner.DisplayName = policy.Owner.DisplayName
+	acl.Owner.ID = policy.Owner.ID
+	acl.CannedACL = cannedACL(policy.Owner.ID, acl.Grants)
+	return acl, nil
+}
+
+// SetObjectACL - replaces the access control list of an object with
+// either the canned ACL or the grants of acl.
+func (c Client) SetObjectACL(bucketName, objectName string, acl ObjectACL) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+	customHeader, err := acl.header()
+	if err != nil {
+		return err
+	}
+	if c.dryRun("set object ACL on %s/%s: %v", bucketName, objectName, customHeader) {
+		return nil
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("acl", "")
+
+	// Execute PUT on object to set its ACL.
+	resp, err := c.executeMethod("PUT", requestMetadata{
+		bucketName:         bucketName,
+		objectName:         objectName,
+		queryValues:        urlValues,
+		customHeader:       customHeader,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return httpRespToErrorResponse(resp, bucketName, objectName)
+		}
+	}
+	return nil
+}
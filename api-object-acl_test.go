@@ -0,0 +1,133 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests getting and setting object ACLs.
+func TestObjectACL(t *testing.T) {
+	var putHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if _, ok := query["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		if _, ok := query["acl"]; !ok || r.URL.Path != "/bucket/object" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case "PUT":
+			putHeader = r.Header
+		case "GET":
+			w.Write([]byte(`<AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Owner><ID>owner-id</ID><DisplayName>owner</DisplayName></Owner>
+  <AccessControlList>
+    <Grant>
+      <Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner-id</ID><DisplayName>owner</DisplayName></Grantee>
+      <Permission>FULL_CONTROL</Permission>
+    </Grant>
+    <Grant>
+      <Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://acs.amazonaws.com/groups/global/AllUsers</URI></Grantee>
+      <Permission>READ</Permission>
+    </Grant>
+  </AccessControlList>
+</AccessControlPolicy>`))
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	acl, err := clnt.GetObjectACL("bucket", "object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if acl.CannedACL != ACLPublicRead || acl.Owner.ID != "owner-id" || len(acl.Grants) != 2 {
+		t.Fatalf("Unexpected ACL %#v", acl)
+	}
+	if grantee := acl.Grants[1].Grantee; grantee.Type != "Group" || grantee.URI != GroupAllUsers {
+		t.Fatalf("Unexpected grantee %#v", grantee)
+	}
+
+	if err = clnt.SetObjectACL("bucket", "object", ObjectACL{CannedACL: ACLPublicRead}); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if putHeader.Get("X-Amz-Acl") != ACLPublicRead {
+		t.Fatalf("Unexpected canned ACL %q", putHeader.Get("X-Amz-Acl"))
+	}
+
+	grants := []Grant{
+		{Grantee: Grantee{ID: "owner-id"}, Permission: PermissionFullControl},
+		{Grantee: Grantee{URI: GroupAllUsers}, Permission: PermissionRead},
+		{Grantee: Grantee{EmailAddress: "user@example.com"}, Permission: PermissionRead},
+	}
+	if err = clnt.SetObjectACL("bucket", "object", ObjectACL{Grants: grants}); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if v := putHeader.Get("X-Amz-Grant-Full-Control"); v != `id="owner-id"` {
+		t.Errorf("Unexpected full control grant %q", v)
+	}
+	if v := putHeader.Get("X-Amz-Grant-Read"); v != `uri="http://acs.amazonaws.com/groups/global/AllUsers", emailAddress="user@example.com"` {
+		t.Errorf("Unexpected read grant %q", v)
+	}
+
+	invalid := []ObjectACL{
+		{},
+		{CannedACL: "everyone"},
+		{CannedACL: ACLPrivate, Grants: grants},
+		{Grants: []Grant{{Grantee: Grantee{ID: "id"}, Permission: "EXECUTE"}}},
+		{Grants: []Grant{{Grantee: Grantee{ID: "id", URI: GroupAllUsers}, Permission: PermissionRead}}},
+	}
+	for i, acl := range invalid {
+		if err = clnt.SetObjectACL("bucket", "object", acl); err == nil {
+			t.Errorf("Test %d: expected to fail", i+1)
+		}
+	}
+}
+
+// Tests canned ACLs are recognized from grants.
+func TestCannedACL(t *testing.T) {
+	owner := Grant{Grantee: Grantee{ID: "owner"}, Permission: PermissionFullControl}
+	group := func(uri, permission string) Grant {
+		return Grant{Grantee: Grantee{URI: uri}, Permission: permission}
+	}
+	testCases := []struct {
+		grants   []Grant
+		expected string
+	}{
+		{[]Grant{owner}, ACLPrivate},
+		{[]Grant{owner, group(GroupAllUsers, PermissionRead)}, ACLPublicRead},
+		{[]Grant{group(GroupAllUsers, PermissionWrite), owner, group(GroupAllUsers, PermissionRead)}, ACLPublicReadWrite},
+		{[]Grant{owner, group(GroupAuthenticatedUsers, PermissionRead)}, ACLAuthenticatedRead},
+		{[]Grant{group(GroupAllUsers, PermissionRead)}, ""},
+		{[]Grant{owner, {Grantee: Grantee{ID: "other"}, Permission: PermissionRead}}, ""},
+	}
+	for i, testCase := range testCases {
+		if canned := cannedACL("owner", testCase.grants); canned != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, canned)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"sync"
+)
+
+// ObjectCache - remembers the ETag and metadata of objects fetched
+// through it and revalidates them with conditional requests, objects
+// which did not change since are not transferred again.
+type ObjectCache struct {
+	mutex  *sync.RWMutex
+	client *Client
+	items  map[string]ObjectInfo
+}
+
+// NewObjectCache - returns an empty object cache issuing its requests
+// with client.
+func NewObjectCache(client *Client) *ObjectCache {
+	return &ObjectCache{
+		mutex:  &sync.RWMutex{},
+		client: client,
+		items:  make(map[string]ObjectInfo),
+	}
+}
+
+// cacheKey - key of an object in the cache.
+func cacheKey(bucketName, objectName string) string {
+	return bucketName + "/" + objectName
+}
+
+// lookup - returns the cached metadata of an object.
+func (o *ObjectCache) lookup(bucketName, objectName string) (ObjectInfo, bool) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	objInfo, ok := o.items[cacheKey(bucketName, objectName)]
+	return objInfo, ok
+}
+
+// update - caches the metadata of a retrieved object, or forgets the
+// object when the request failed for another reason than the object
+// not being modified.
+func (o *ObjectCache) update(bucketName, objectName string, objInfo ObjectInfo, err error) {
+	switch {
+	case err == nil && objInfo.ETag != "":
+		o.mutex.Lock()
+		o.items[cacheKey(bucketName, objectName)] = objInfo
+		o.mutex.Unlock()
+	case err != nil && ToErrorResponse(err).Code != "NotModified":
+		o.Forget(bucketName, objectName)
+	}
+}
+
+// conditionalHeaders - returns request headers asking the server to
+// answer with '304 Not Modified' when the object still has the cached
+// ETag.
+func (o *ObjectCache) conditionalHeaders(bucketName, objectName string) (RequestHeaders, ObjectInfo, bool) {
+	reqHeaders := NewGetReqHeaders()
+	objInfo, ok := o.lookup(bucketName, objectName)
+	if ok {
+		reqHeaders.SetMatchETagExcept(objInfo.ETag)
+	}
+	return reqHeaders, objInfo, ok
+}
+
+// GetObject - downloads an object unless it still has the ETag it had
+// when it was last retrieved through the cache. In that case no data
+// is transferred, the cached metadata is returned along with
+// ErrNotModified.
+func (o *ObjectCache) GetObject(bucketName, objectName string) (io.ReadCloser, ObjectInfo, error) {
+	reqHeaders, cached, ok := o.conditionalHeaders(bucketName, objectName)
+	reader, objInfo, err := o.client.getObject(bucketName, objectName, reqHeaders)
+	o.update(bucketName, objectName, objInfo, err)
+	if ok && ToErrorResponse(err).Code == "NotModified" {
+		return nil, cached, ErrNotModified(bucketName, objectName)
+	}
+	return reader, objInfo, err
+}
+
+// StatObject - fetches the metadata of an object, returning the cached
+// metadata along with ErrNotModified when the object still has the
+// ETag it had when it was last retrieved through the cache.
+func (o *ObjectCache) StatObject(bucketName, objectName string) (ObjectInfo, error) {
+	reqHeaders, cached, ok := o.conditionalHeaders(bucketName, objectName)
+	objInfo, err := o.client.statObject(bucketName, objectName, reqHeaders)
+	o.update(bucketName, objectName, objInfo, err)
+	if ok && ToErrorResponse(err).Code == "NotModified" {
+		return cached, ErrNotModified(bucketName, objectName)
+	}
+	return objInfo, err
+}
+
+// Forget - removes an object from the cache, it is retrieved
+// unconditionally next time.
+func (o *ObjectCache) Forget(bucketName, objectName string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	delete(o.items, cacheKey(bucketName, objectName))
+}
@@ -0,0 +1,93 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests unchanged objects are revalidated without being downloaded again.
+func TestObjectCache(t *testing.T) {
+	etag := "etag1"
+	var downloads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		if r.Header.Get("If-None-Match") == "\""+etag+"\"" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", "\""+etag+"\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("X-Amz-Meta-Version", etag)
+		if r.Method == "GET" {
+			downloads++
+			w.Write([]byte(etag))
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	cache := NewObjectCache(clnt)
+
+	read := func() (string, ObjectInfo, error) {
+		reader, objInfo, err := cache.GetObject("bucket", "object")
+		if err != nil {
+			return "", objInfo, err
+		}
+		defer reader.Close()
+		data, err := ioutil.ReadAll(reader)
+		return string(data), objInfo, err
+	}
+
+	if data, objInfo, err := read(); err != nil || data != "etag1" || objInfo.ETag != "etag1" {
+		t.Fatalf("Unexpected first download %q, %v, %v", data, objInfo.ETag, err)
+	}
+	if _, objInfo, err := read(); ToErrorResponse(err).Code != "NotModified" || objInfo.UserMetadata["Version"] != "etag1" {
+		t.Fatalf("Expected NotModified with cached metadata, got %v, %v", objInfo, err)
+	}
+	if objInfo, err := cache.StatObject("bucket", "object"); ToErrorResponse(err).Code != "NotModified" || objInfo.ETag != "etag1" {
+		t.Fatalf("Expected NotModified with cached metadata, got %v, %v", objInfo, err)
+	}
+	if downloads != 1 {
+		t.Fatalf("Expected a single download, got %d", downloads)
+	}
+
+	etag = "etag2"
+	if objInfo, err := cache.StatObject("bucket", "object"); err != nil || objInfo.ETag != "etag2" {
+		t.Fatalf("Expected changed object, got %v, %v", objInfo, err)
+	}
+	// The stat refreshed the cached ETag.
+	if _, _, err := read(); ToErrorResponse(err).Code != "NotModified" {
+		t.Fatalf("Expected NotModified, got %v", err)
+	}
+
+	cache.Forget("bucket", "object")
+	if data, _, err := read(); err != nil || data != "etag2" {
+		t.Fatalf("Unexpected download %q, %v", data, err)
+	}
+}
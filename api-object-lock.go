@@ -0,0 +1,199 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// objectLegalHold - legal hold of an object as sent and received.
+type objectLegalHold struct {
+	XMLName xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LegalHold"`
+	Status  LegalHoldStatus `xml:"Status"`
+}
+
+// objectRetention - retention of an object as sent and received.
+type objectRetention struct {
+	XMLName         xml.Name      `xml:"http://s3.amazonaws.com/doc/2006-03-01/ Retention"`
+	Mode            RetentionMode `xml:"Mode,omitempty"`
+	RetainUntilDate string        `xml:"RetainUntilDate,omitempty"`
+}
+
+// putObjectLockConfig - sends config to the object lock sub-resource
+// of an object version, the latest version when versionID is empty.
+func (c Client) putObjectLockConfig(bucketName, objectName, versionID, resource string, config interface{}) error {
+	// Get resources properly escaped and lined up before
+	// using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set(resource, "")
+	if versionID != "" {
+		urlValues.Set("versionId", versionID)
+	}
+
+	configBytes, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if c.dryRun("set object %s on %s/%s: %s", resource, bucketName, objectName, configBytes) {
+		return nil
+	}
+
+	reqMetadata := requestMetadata{
+		bucketName:         bucketName,
+		objectName:         objectName,
+		queryValues:        urlValues,
+		contentBody:        bytes.NewReader(configBytes),
+		contentLength:      int64(len(configBytes)),
+		contentMD5Bytes:    sumMD5(configBytes),
+		contentSHA256Bytes: sum256(configBytes),
+	}
+
+	// Execute PUT on the object lock sub-resource.
+	resp, err := c.executeMethod("PUT", reqMetadata)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return httpRespToErrorResponse(resp, bucketName, objectName)
+		}
+	}
+	return nil
+}
+
+// getObjectLockConfig - decodes the object lock sub-resource of an
+// object version into config, the latest version when versionID is
+// empty.
+func (c Client) getObjectLockConfig(bucketName, objectName, versionID, resource string, config interface{}) error {
+	urlValues := make(url.Values)
+	urlValues.Set(resource, "")
+	if versionID != "" {
+		urlValues.Set("versionId", versionID)
+	}
+
+	// Execute GET on the object lock sub-resource.
+	resp, err := c.executeMethod("GET", requestMetadata{
+		bucketName:         bucketName,
+		objectName:         objectName,
+		queryValues:        urlValues,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return httpRespToErrorResponse(resp, bucketName, objectName)
+		}
+	}
+	return xmlDecoder(resp.Body, config)
+}
+
+// PutObjectLegalHold - places or releases a legal hold on an existing
+// object, on its latest version when versionID is empty. The bucket
+// must have object lock enabled.
+func (c Client) PutObjectLegalHold(bucketName, objectName, versionID string, status LegalHoldStatus) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+	if !status.IsValid() {
+		return ErrInvalidArgument("Legal hold status should be either ON or OFF.")
+	}
+	return c.putObjectLockConfig(bucketName, objectName, versionID, "legal-hold", objectLegalHold{Status: status})
+}
+
+// GetObjectLegalHold - returns the legal hold status of an object, of
+// its latest version when versionID is empty.
+func (c Client) GetObjectLegalHold(bucketName, objectName, versionID string) (LegalHoldStatus, error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return "", err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return "", err
+	}
+	legalHold := objectLegalHold{}
+	if err := c.getObjectLockConfig(bucketName, objectName, versionID, "legal-hold", &legalHold); err != nil {
+		return "", err
+	}
+	return legalHold.Status, nil
+}
+
+// PutObjectRetention - sets the retention mode and period of an
+// existing object, of its latest version when versionID is empty.
+// Retention can be extended, shortening it is refused by the server
+// unless the mode is governance and the caller is allowed to bypass it.
+func (c Client) PutObjectRetention(bucketName, objectName, versionID string, mode RetentionMode, retainUntilDate time.Time) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+	if !mode.IsValid() {
+		return ErrInvalidArgument("Retention mode should be either GOVERNANCE or COMPLIANCE.")
+	}
+	if err := (ObjectLock{Mode: mode, RetainUntilDate: retainUntilDate}).validate(); err != nil {
+		return err
+	}
+	return c.putObjectLockConfig(bucketName, objectName, versionID, "retention", objectRetention{
+		Mode:            mode,
+		RetainUntilDate: retainUntilDate.UTC().Format(time.RFC3339),
+	})
+}
+
+// GetObjectRetention - returns the retention mode and period of an
+// object, of its latest version when versionID is empty.
+func (c Client) GetObjectRetention(bucketName, objectName, versionID string) (mode RetentionMode, retainUntilDate time.Time, err error) {
+	// Input validation.
+	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+		return "", time.Time{}, err
+	}
+	if err = s3utils.CheckValidObjectName(objectName); err != nil {
+		return "", time.Time{}, err
+	}
+	retention := objectRetention{}
+	if err = c.getObjectLockConfig(bucketName, objectName, versionID, "retention", &retention); err != nil {
+		return "", time.Time{}, err
+	}
+	if retention.RetainUntilDate != "" {
+		retainUntilDate, err = time.Parse(time.RFC3339, retention.RetainUntilDate)
+		if err != nil {
+			return "", time.Time{}, ErrorResponse{
+				Code:       "InternalError",
+				Message:    "Retain until date format is invalid. " + reportIssue,
+				BucketName: bucketName,
+				Key:        objectName,
+			}
+		}
+	}
+	return retention.Mode, retainUntilDate, nil
+}
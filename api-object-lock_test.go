@@ -0,0 +1,164 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests legal holds and retention of existing objects are set and read.
+func TestObjectLegalHoldAndRetention(t *testing.T) {
+	stored := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		key := r.URL.Path + "?" + r.URL.RawQuery
+		switch r.Method {
+		case "PUT":
+			if r.Header.Get("Content-Md5") == "" {
+				t.Errorf("Expected Content-Md5 to be set on %s", key)
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			stored[key] = string(body)
+		case "GET":
+			body, ok := stored[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	if err = clnt.PutObjectLegalHold("bucket", "object", "v1", LegalHoldOn); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if body := stored["/bucket/object?legal-hold=&versionId=v1"]; !strings.Contains(body, "<Status>ON</Status>") {
+		t.Fatalf("Unexpected legal hold %q", body)
+	}
+	status, err := clnt.GetObjectLegalHold("bucket", "object", "v1")
+	if err != nil || status != LegalHoldOn {
+		t.Fatalf("Expected legal hold ON, got %q, %v", status, err)
+	}
+	if _, err = clnt.GetObjectLegalHold("bucket", "object", ""); err == nil {
+		t.Fatal("Expected legal hold of the latest version to be missing")
+	}
+
+	until := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	if err = clnt.PutObjectRetention("bucket", "object", "", Governance, until); err != nil {
+		t.Fatal("Error:", err)
+	}
+	mode, retainUntilDate, err := clnt.GetObjectRetention("bucket", "object", "")
+	if err != nil || mode != Governance || !retainUntilDate.Equal(until) {
+		t.Fatalf("Expected retention %s until %v, got %s until %v, %v", Governance, until, mode, retainUntilDate, err)
+	}
+
+	// Invalid settings are refused before sending requests.
+	if err = clnt.PutObjectLegalHold("bucket", "object", "", "MAYBE"); err == nil {
+		t.Fatal("Expected invalid legal hold status to fail")
+	}
+	if err = clnt.PutObjectRetention("bucket", "object", "", "", until); err == nil {
+		t.Fatal("Expected missing retention mode to fail")
+	}
+	if err = clnt.PutObjectRetention("bucket", "object", "", Compliance, time.Now().Add(-time.Hour)); err == nil {
+		t.Fatal("Expected past retain until date to fail")
+	}
+}
+
+// Tests governance retention is bypassed only on request.
+func TestRemoveObjectGovernanceBypass(t *testing.T) {
+	type request struct {
+		query  string
+		bypass string
+	}
+	var requests []request
+	var deletes []deleteMultiObjects
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		requests = append(requests, request{r.URL.RawQuery, r.Header.Get(amzBypassGovernance)})
+		if r.Method == "POST" {
+			var rmObjects deleteMultiObjects
+			body, _ := ioutil.ReadAll(r.Body)
+			xml.Unmarshal(body, &rmObjects)
+			deletes = append(deletes, rmObjects)
+			w.Write([]byte(`<DeleteResult></DeleteResult>`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	if err = clnt.RemoveObject("bucket", "object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.RemoveObjectWithOptions("bucket", "object", RemoveObjectOptions{VersionID: "v1", GovernanceBypass: true}); err != nil {
+		t.Fatal("Error:", err)
+	}
+	objectsCh := make(chan string, 1)
+	objectsCh <- "object"
+	close(objectsCh)
+	for rmErr := range clnt.RemoveObjects("bucket", objectsCh) {
+		t.Fatal("Error:", rmErr.Err)
+	}
+	versionsCh := make(chan ObjectVersion, 2)
+	versionsCh <- ObjectVersion{ObjectName: "object", VersionID: "v2"}
+	versionsCh <- ObjectVersion{ObjectName: "other"}
+	close(versionsCh)
+	for rmErr := range clnt.RemoveObjectsWithOptions("bucket", versionsCh, RemoveObjectsOptions{GovernanceBypass: true}) {
+		t.Fatal("Error:", rmErr.Err)
+	}
+
+	expected := []request{{"", ""}, {"versionId=v1", "true"}, {"delete=", ""}, {"delete=", "true"}}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected %d requests, got %v", len(expected), requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("Request %d: expected %v, got %v", i+1, expected[i], requests[i])
+		}
+	}
+	// Versions to remove are sent along with the keys.
+	if len(deletes) != 2 || len(deletes[0].Objects) != 1 || deletes[0].Objects[0] != (deleteObject{Key: "object"}) {
+		t.Fatalf("Unexpected multi delete requests %+v", deletes)
+	}
+	if len(deletes[1].Objects) != 2 || deletes[1].Objects[0] != (deleteObject{Key: "object", VersionID: "v2"}) || deletes[1].Objects[1] != (deleteObject{Key: "other"}) {
+		t.Fatalf("Unexpected versioned multi delete request %+v", deletes[1])
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"html"
+	"sort"
+)
+
+// PostFormField - a field of a browser upload form.
+type PostFormField struct {
+	Name  string
+	Value string
+}
+
+// PostForm - everything needed to render a form uploading an object
+// straight from a browser, as allowed by a post policy.
+type PostForm struct {
+	// URL the form is posted to.
+	URL string
+	// Fields are sent as hidden inputs ahead of the file input,
+	// which the server requires to be the last field.
+	Fields []PostFormField
+}
+
+// PresignedPostForm - signs the post policy p and returns the target
+// URL along with all the form fields, the object key first and the
+// others sorted by name. The form fields include the signed policy,
+// the signature, and for signature V4 the credential, date and the
+// session token of temporary credentials.
+func (c Client) PresignedPostForm(p *PostPolicy) (PostForm, error) {
+	u, formData, err := c.PresignedPostPolicy(p)
+	if err != nil {
+		return PostForm{}, err
+	}
+
+	var names []string
+	for name := range formData {
+		if name != "key" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	form := PostForm{
+		URL:    u.String(),
+		Fields: []PostFormField{{Name: "key", Value: formData["key"]}},
+	}
+	for _, name := range names {
+		form.Fields = append(form.Fields, PostFormField{Name: name, Value: formData[name]})
+	}
+	return form, nil
+}
+
+// HTML - renders the form as HTML with a file input and a submit
+// button, all names and values are escaped.
+func (f PostForm) HTML() string {
+	var buf bytes.Buffer
+	buf.WriteString(`<form action="` + html.EscapeString(f.URL) + `" method="post" enctype="multipart/form-data">` + "\n")
+	for _, field := range f.Fields {
+		buf.WriteString(`  <input type="hidden" name="` + html.EscapeString(field.Name) + `" value="` + html.EscapeString(field.Value) + `">` + "\n")
+	}
+	buf.WriteString(`  <input type="file" name="file">` + "\n")
+	buf.WriteString(`  <input type="submit" value="Upload">` + "\n")
+	buf.WriteString(`</form>` + "\n")
+	return buf.String()
+}
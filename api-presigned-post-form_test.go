@@ -0,0 +1,69 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/pkg/credentials"
+)
+
+// Tests post policies are turned into complete upload forms.
+func TestPresignedPostForm(t *testing.T) {
+	clnt, err := NewWithCredentials("localhost:9000", credentials.NewStaticV4("access", "secret", "token"), false, "us-east-1")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	policy := NewPostPolicy()
+	policy.SetBucket("bucket")
+	policy.SetKey("uploads/<photo>.jpg")
+	policy.SetExpires(time.Now().UTC().Add(time.Hour))
+	form, err := clnt.PresignedPostForm(policy)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	if form.URL != "http://localhost:9000/bucket/" {
+		t.Errorf("Unexpected form URL %s", form.URL)
+	}
+	var names []string
+	for _, field := range form.Fields {
+		names = append(names, field.Name)
+	}
+	expected := "key,bucket,policy,x-amz-algorithm,x-amz-credential,x-amz-date,x-amz-security-token,x-amz-signature"
+	if got := strings.Join(names, ","); got != expected {
+		t.Fatalf("Expected fields %s, got %s", expected, got)
+	}
+	if form.Fields[0].Value != "uploads/<photo>.jpg" || form.Fields[6].Value != "token" {
+		t.Errorf("Unexpected field values %v", form.Fields)
+	}
+
+	formHTML := form.HTML()
+	if !strings.HasPrefix(formHTML, `<form action="http://localhost:9000/bucket/" method="post" enctype="multipart/form-data">`) {
+		t.Errorf("Unexpected form %s", formHTML)
+	}
+	if !strings.Contains(formHTML, `<input type="hidden" name="key" value="uploads/&lt;photo&gt;.jpg">`) {
+		t.Errorf("Expected escaped key in %s", formHTML)
+	}
+	if strings.Index(formHTML, `type="file"`) < strings.LastIndex(formHTML, `type="hidden"`) {
+		t.Errorf("Expected file input after all fields in %s", formHTML)
+	}
+}
@@ -25,7 +25,7 @@ import (
 	"github.com/minio/minio-go/pkg/s3utils"
 )
 
-// supportedGetReqParams - supported request parameters for GET presigned request.
+// supportedGetReqParams - supported request parameters for GET and HEAD presigned request.
 var supportedGetReqParams = map[string]struct{}{
 	"response-expires":             {},
 	"response-content-type":        {},
@@ -35,9 +35,20 @@ var supportedGetReqParams = map[string]struct{}{
 	"response-content-disposition": {},
 }
 
+// PresignOptions - signing time settings of a presigned URL.
+type PresignOptions struct {
+	// Time the URL is signed at, defaults to now as corrected by the
+	// clock offset learned from the server.
+	SignTime time.Time
+	// Allowed difference between the signing clock and the server
+	// clock. The URL is valid from SignTime - ClockSkew until
+	// SignTime + expires + ClockSkew.
+	ClockSkew time.Duration
+}
+
 // presignURL - Returns a presigned URL for an input 'method'.
 // Expires maximum is 7days - ie. 604800 and minimum is 1.
-func (c Client) presignURL(method string, bucketName string, objectName string, expires time.Duration, reqParams url.Values) (u *url.URL, err error) {
+func (c Client) presignURL(method string, bucketName string, objectName string, expires time.Duration, reqParams url.Values, opts PresignOptions) (u *url.URL, err error) {
 	// Input validation.
 	if method == "" {
 		return nil, ErrInvalidArgument("method cannot be empty.")
@@ -51,6 +62,20 @@ func (c Client) presignURL(method string, bucketName string, objectName string,
 	if err := isValidExpiry(expires); err != nil {
 		return nil, err
 	}
+	if opts.ClockSkew < 0 {
+		return nil, ErrInvalidArgument("Clock skew cannot be negative.")
+	}
+
+	// Widen the validity window by the clock skew on both ends.
+	signTime := opts.SignTime
+	if signTime.IsZero() {
+		signTime = c.now()
+	}
+	signTime = signTime.Add(-opts.ClockSkew)
+	expires += 2 * opts.ClockSkew
+	if err := isValidExpiry(expires); err != nil {
+		return nil, err
+	}
 
 	// Convert expires into seconds.
 	expireSeconds := int64(expires / time.Second)
@@ -59,18 +84,19 @@ func (c Client) presignURL(method string, bucketName string, objectName string,
 		bucketName: bucketName,
 		objectName: objectName,
 		expires:    expireSeconds,
+		signTime:   signTime,
 	}
 
-	// For "GET" we are handling additional request parameters to
-	// override its response headers.
-	if method == "GET" {
+	// For "GET" and "HEAD" we are handling additional request
+	// parameters to override its response headers.
+	if method == "GET" || method == "HEAD" {
 		// Verify if input map has unsupported params, if yes exit.
 		for k := range reqParams {
 			if _, ok := supportedGetReqParams[k]; !ok {
-				return nil, ErrInvalidArgument(k + " unsupported request parameter for presigned GET.")
+				return nil, ErrInvalidArgument(k + " unsupported request parameter for presigned " + method + ".")
 			}
 		}
-		// Save the request parameters to be used in presigning for GET request.
+		// Save the request parameters to be used in presigning for GET and HEAD request.
 		reqMetadata.queryValues = reqParams
 	}
 
@@ -88,13 +114,30 @@ func (c Client) presignURL(method string, bucketName string, objectName string,
 // minimum is 1. Additionally you can override a set of response
 // headers using the query parameters.
 func (c Client) PresignedGetObject(bucketName string, objectName string, expires time.Duration, reqParams url.Values) (u *url.URL, err error) {
-	return c.presignURL("GET", bucketName, objectName, expires, reqParams)
+	return c.presignURL("GET", bucketName, objectName, expires, reqParams, PresignOptions{})
+}
+
+// PresignedHeadObject - Returns a presigned URL to access object
+// metadata without credentials, for example to check existence and
+// size of an object before downloading it. Expires maximum is 7days
+// - ie. 604800 and minimum is 1. Additionally you can override a set
+// of response headers using the query parameters.
+func (c Client) PresignedHeadObject(bucketName string, objectName string, expires time.Duration, reqParams url.Values) (u *url.URL, err error) {
+	return c.presignURL("HEAD", bucketName, objectName, expires, reqParams, PresignOptions{})
 }
 
 // PresignedPutObject - Returns a presigned URL to upload an object without credentials.
 // Expires maximum is 7days - ie. 604800 and minimum is 1.
 func (c Client) PresignedPutObject(bucketName string, objectName string, expires time.Duration) (u *url.URL, err error) {
-	return c.presignURL("PUT", bucketName, objectName, expires, nil)
+	return c.presignURL("PUT", bucketName, objectName, expires, nil, PresignOptions{})
+}
+
+// Presign - Returns a presigned URL for method with explicit signing
+// time settings, for clients whose clock cannot be trusted. Expires
+// maximum is 7days - ie. 604800 and minimum is 1, including twice the
+// clock skew. Request parameters are supported for GET and HEAD.
+func (c Client) Presign(method string, bucketName string, objectName string, expires time.Duration, reqParams url.Values, opts PresignOptions) (u *url.URL, err error) {
+	return c.presignURL(method, bucketName, objectName, expires, reqParams, opts)
 }
 
 // PresignedPostPolicy - Returns POST urlString, form data to upload an object.
@@ -140,7 +183,7 @@ func (c Client) PresignedPostPolicy(p *PostPolicy) (u *url.URL, formData map[str
 	}
 
 	// Keep time.
-	t := time.Now().UTC()
+	t := c.now()
 	// For signature version '2' handle here.
 	if signerType.IsV2() {
 		policyBase64 := p.base64()
@@ -39,9 +39,15 @@ import (
 // For Amazon S3 for more supported regions - http://docs.aws.amazon.com/general/latest/gr/rande.html
 // For Google Cloud Storage for more supported regions - https://cloud.google.com/storage/docs/bucket-locations
 func (c Client) MakeBucket(bucketName string, location string) (err error) {
+	return c.makeBucket(bucketName, location, nil)
+}
+
+// makeBucket - creates bucketName in location sending the additional
+// headers in customHeader.
+func (c Client) makeBucket(bucketName string, location string, customHeader http.Header) (err error) {
 	defer func() {
 		// Save the location into cache on a successful makeBucket response.
-		if err == nil {
+		if err == nil && !c.isDryRun {
 			c.bucketLocCache.Set(bucketName, location)
 		}
 	}()
@@ -60,10 +66,15 @@ func (c Client) MakeBucket(bucketName string, location string) (err error) {
 			location = c.region
 		}
 	}
+	if c.dryRun("make bucket %s in %s", bucketName, location) {
+		return nil
+	}
+
 	// PUT bucket request metadata.
 	reqMetadata := requestMetadata{
 		bucketName:     bucketName,
 		bucketLocation: location,
+		customHeader:   customHeader,
 	}
 
 	// If location is not 'us-east-1' create bucket location config.
@@ -118,6 +129,9 @@ func (c Client) SetBucketPolicy(bucketName string, objectPrefix string, bucketPo
 	if !bucketPolicy.IsValidBucketPolicy() {
 		return ErrInvalidArgument(fmt.Sprintf("Invalid bucket policy provided. %s", bucketPolicy))
 	}
+	if c.dryRun("set bucket policy %s on %s/%s", bucketPolicy, bucketName, objectPrefix) {
+		return nil
+	}
 
 	policyInfo, err := c.getBucketPolicy(bucketName)
 	errResponse := ToErrorResponse(err)
@@ -137,6 +151,20 @@ func (c Client) SetBucketPolicy(bucketName string, objectPrefix string, bucketPo
 	return c.putBucketPolicy(bucketName, policyInfo)
 }
 
+// SetBucketAccessPolicy - replaces the access policy of a bucket, such
+// as one built with policy.NewBuilder. A policy without statements
+// removes the bucket policy.
+func (c Client) SetBucketAccessPolicy(bucketName string, bucketPolicy policy.BucketAccessPolicy) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if c.dryRun("set bucket access policy on %s", bucketName) {
+		return nil
+	}
+	return c.putBucketPolicy(bucketName, bucketPolicy)
+}
+
 // Saves a new bucket policy.
 func (c Client) putBucketPolicy(bucketName string, policyInfo policy.BucketAccessPolicy) error {
 	// Input validation.
@@ -223,6 +251,9 @@ func (c Client) SetBucketNotification(bucketName string, bucketNotification Buck
 	if err != nil {
 		return err
 	}
+	if c.dryRun("set bucket notification on %s: %s", bucketName, notifBytes) {
+		return nil
+	}
 
 	notifBuffer := bytes.NewReader(notifBytes)
 	reqMetadata := requestMetadata{
@@ -0,0 +1,75 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio-go/pkg/policy"
+)
+
+// Tests built access policies are saved as is.
+func TestSetBucketAccessPolicy(t *testing.T) {
+	var stored []byte
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if _, ok := query["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		if _, ok := query["policy"]; !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case "PUT":
+			stored, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.SetBucketAccessPolicy("bucket", policy.NewBuilder("bucket").ReadOnly("public/").Policy()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	policies, err := policy.GetPoliciesFromJSON(stored, "bucket")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(policies) != 1 || policies["bucket/public/*"] != policy.BucketPolicyReadOnly {
+		t.Fatalf("Unexpected policies %v", policies)
+	}
+
+	if err = clnt.SetBucketAccessPolicy("bucket", policy.NewBuilder("bucket").Policy()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if !deleted {
+		t.Fatal("Expected empty policy to remove the bucket policy")
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import "sort"
+
+// SetCopyConcurrency - sets the number of parts copied in parallel
+// when objects are composed server side from copied parts by
+// AppendObject, values below 1 restore the default of 3. CopyObject
+// copies in a single request and is not affected.
+func (c *Client) SetCopyConcurrency(n int) {
+	c.copyConcurrency = n
+}
+
+// copyWorkers - returns the number of parts to copy in parallel.
+func (c Client) copyWorkers() int {
+	if c.copyConcurrency < 1 {
+		return totalWorkers
+	}
+	return c.copyConcurrency
+}
+
+// copyPartInfo - returns the parts size bytes are copied in. Copied
+// parts are followed by further parts, so unlike the last part of an
+// upload the last copied part cannot be smaller than absMinPartSize;
+// a smaller remainder is folded into the previous part.
+func copyPartInfo(size int64) (totalPartsCount int, partSize int64, lastPartSize int64, err error) {
+	totalPartsCount, partSize, lastPartSize, err = optimalPartInfo(size)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if totalPartsCount > 1 && lastPartSize < absMinPartSize {
+		totalPartsCount--
+		lastPartSize += partSize
+	}
+	return totalPartsCount, partSize, lastPartSize, nil
+}
+
+// copyObjectParts - copies size bytes of objectSource into the parts
+// of uploadID, numbered from 1, copying parts in parallel. The copied
+// parts are returned sorted by part number.
+func (c Client) copyObjectParts(bucketName, objectName, uploadID, objectSource string, size int64, cpCond CopyConditions) ([]CompletePart, error) {
+	// Calculate the parts info for the source size.
+	totalPartsCount, partSize, lastPartSize, err := copyPartInfo(size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send each part through copyPartsCh to be copied.
+	copyPartsCh := make(chan uploadPartReq, totalPartsCount)
+	for p := 1; p <= totalPartsCount; p++ {
+		copyPartsCh <- uploadPartReq{PartNum: p}
+	}
+	close(copyPartsCh)
+
+	// Copied parts are returned through copiedPartsCh, buffered so
+	// that workers never block once copying was given up.
+	copiedPartsCh := make(chan uploadedPartRes, totalPartsCount)
+	// Closed on return, workers stop copying parts.
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	workers := c.copyWorkers()
+	if workers > totalPartsCount {
+		workers = totalPartsCount
+	}
+	for w := 1; w <= workers; w++ {
+		go func() {
+			for copyReq := range copyPartsCh {
+				select {
+				case <-doneCh:
+					return
+				default:
+				}
+
+				length := partSize
+				if copyReq.PartNum == totalPartsCount {
+					length = lastPartSize
+				}
+				startOffset := int64(copyReq.PartNum-1) * partSize
+
+				objPart, err := c.uploadPartCopy(bucketName, objectName, uploadID, copyReq.PartNum, objectSource, startOffset, length, cpCond)
+				if err != nil {
+					copiedPartsCh <- uploadedPartRes{Error: err}
+					return
+				}
+				copiedPartsCh <- uploadedPartRes{
+					PartNum: copyReq.PartNum,
+					Size:    length,
+					Part:    &objPart,
+				}
+			}
+		}()
+	}
+
+	// Retrieve each copied part once it is done.
+	parts := make([]CompletePart, 0, totalPartsCount)
+	for u := 1; u <= totalPartsCount; u++ {
+		copyRes := <-copiedPartsCh
+		if copyRes.Error != nil {
+			return nil, copyRes.Error
+		}
+		parts = append(parts, CompletePart{
+			ETag:       copyRes.Part.ETag,
+			PartNumber: copyRes.Part.PartNumber,
+		})
+	}
+	sort.Sort(completedParts(parts))
+	return parts, nil
+}
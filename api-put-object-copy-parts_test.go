@@ -0,0 +1,107 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Tests parts are copied in parallel up to the copy concurrency.
+func TestCopyObjectParts(t *testing.T) {
+	var mutex sync.Mutex
+	var inFlight, maxInFlight int
+	ranges := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		ranges[r.URL.Query().Get("partNumber")] = r.Header.Get("x-amz-copy-source-range")
+		mutex.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+		fmt.Fprintf(w, `<CopyPartResult><ETag>"etag-%s"</ETag></CopyPartResult>`, r.URL.Query().Get("partNumber"))
+	}))
+	defer server.Close()
+
+	clnt, err := NewWithRegion(server.Listener.Addr().String(), "access", "secret", false, "us-east-1")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	clnt.SetCopyConcurrency(2)
+
+	// Four parts of the minimum part size, the last one too small
+	// to be followed by another part and folded into the third.
+	size := int64(3*minPartSize + 10)
+	parts, err := clnt.copyObjectParts("bucket", "object", "upload", "bucket/source", size, CopyConditions{})
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(parts) != 3 {
+		t.Fatalf("Expected 3 parts, got %d", len(parts))
+	}
+	for i, part := range parts {
+		if part.PartNumber != i+1 || part.ETag != fmt.Sprintf("etag-%d", i+1) {
+			t.Fatalf("Unexpected part %d: %v", i+1, part)
+		}
+	}
+	if maxInFlight != 2 {
+		t.Fatalf("Expected 2 parts copied in parallel, got %d", maxInFlight)
+	}
+	if expected := fmt.Sprintf("bytes=%d-%d", 2*minPartSize, size-1); ranges["3"] != expected {
+		t.Fatalf("Expected last part range %s, got %s", expected, ranges["3"])
+	}
+}
+
+// Tests no copied part is smaller than the minimum part size.
+func TestCopyPartInfo(t *testing.T) {
+	sizes := []int64{
+		absMinPartSize,
+		minPartSize,
+		minPartSize + 1,
+		minPartSize + absMinPartSize - 1,
+		minPartSize + absMinPartSize,
+		3*minPartSize + 10,
+		maxMultipartPutObjectSize,
+	}
+	for i, size := range sizes {
+		totalPartsCount, partSize, lastPartSize, err := copyPartInfo(size)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if int64(totalPartsCount-1)*partSize+lastPartSize != size {
+			t.Errorf("Test %d: parts do not add up to %d", i+1, size)
+		}
+		if totalPartsCount > 1 && partSize < absMinPartSize {
+			t.Errorf("Test %d: part size %d is too small", i+1, partSize)
+		}
+		if lastPartSize < absMinPartSize || lastPartSize > maxPartSize {
+			t.Errorf("Test %d: last copied part size %d is out of bounds", i+1, lastPartSize)
+		}
+	}
+}
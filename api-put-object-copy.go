@@ -18,21 +18,32 @@ package minio
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/minio/minio-go/pkg/s3utils"
 )
 
 // CopyObject - copy a source object into a new object with the provided name in the provided bucket
 func (c Client) CopyObject(bucketName string, objectName string, objectSource string, cpCond CopyConditions) error {
+	_, err := c.copyObjectDo(bucketName, objectName, objectSource, cpCond)
+	return err
+}
+
+// copyObjectDo - executes the copy object http operation and returns
+// the decoded copy result with its ETag trimmed of double quotes.
+func (c Client) copyObjectDo(bucketName string, objectName string, objectSource string, cpCond CopyConditions) (copyObjectResult, error) {
 	// Input validation.
 	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
-		return err
+		return copyObjectResult{}, err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
-		return err
+		return copyObjectResult{}, err
 	}
 	if objectSource == "" {
-		return ErrInvalidArgument("Object source cannot be empty.")
+		return copyObjectResult{}, ErrInvalidArgument("Object source cannot be empty.")
+	}
+	if c.dryRun("copy object %s to %s/%s", objectSource, bucketName, objectName) {
+		return copyObjectResult{}, nil
 	}
 
 	// customHeaders apply headers.
@@ -52,11 +63,11 @@ func (c Client) CopyObject(bucketName string, objectName string, objectSource st
 	})
 	defer closeResponse(resp)
 	if err != nil {
-		return err
+		return copyObjectResult{}, err
 	}
 	if resp != nil {
 		if resp.StatusCode != http.StatusOK {
-			return httpRespToErrorResponse(resp, bucketName, objectName)
+			return copyObjectResult{}, httpRespToErrorResponse(resp, bucketName, objectName)
 		}
 	}
 
@@ -64,9 +75,13 @@ func (c Client) CopyObject(bucketName string, objectName string, objectSource st
 	cpObjRes := copyObjectResult{}
 	err = xmlDecoder(resp.Body, &cpObjRes)
 	if err != nil {
-		return err
+		return copyObjectResult{}, err
 	}
 
-	// Return nil on success.
-	return nil
+	// Trim off the odd double quotes from ETag in the beginning and end.
+	cpObjRes.ETag = strings.TrimPrefix(cpObjRes.ETag, "\"")
+	cpObjRes.ETag = strings.TrimSuffix(cpObjRes.ETag, "\"")
+
+	// Return the copy result on success.
+	return cpObjRes, nil
 }
@@ -72,6 +72,10 @@ func (c Client) FPutObject(bucketName, objectName, filePath, contentType string)
 
 	objMetadata["Content-Type"] = []string{contentType}
 
+	if c.isDryRun {
+		return c.dryRunPutObject(bucketName, objectName, fileSize)
+	}
+
 	// NOTE: Google Cloud Storage multipart Put is not compatible with Amazon S3 APIs.
 	if s3utils.IsGoogleEndpoint(c.endpointURL) {
 		// Do not compute MD5 for Google Cloud Storage.
@@ -167,7 +171,7 @@ func (c Client) putObjectMultipartFromFile(bucketName, objectName string, fileRe
 			for uploadReq := range uploadPartsCh {
 				// Add hash algorithms that need to be calculated by computeHash()
 				// In case of a non-v4 signature or https connection, sha256 is not needed.
-				hashAlgos, hashSums := c.hashMaterials()
+				hashAlgos, hashSums := c.hashMaterialsFor(metaData)
 
 				// If partNumber was not uploaded we calculate the missing
 				// part offset and size. For all other part numbers we
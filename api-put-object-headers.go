@@ -0,0 +1,81 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// ObjectHeaders - standard HTTP headers stored along with an object
+// and returned when the object is downloaded, they control how
+// browsers and CDNs cache and present the object.
+type ObjectHeaders struct {
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	ContentType        string
+	Expires            time.Time
+}
+
+// header - returns the headers which are set as request headers.
+func (h ObjectHeaders) header() http.Header {
+	header := make(http.Header)
+	if h.CacheControl != "" {
+		header.Set("Cache-Control", h.CacheControl)
+	}
+	if h.ContentDisposition != "" {
+		header.Set("Content-Disposition", h.ContentDisposition)
+	}
+	if h.ContentEncoding != "" {
+		header.Set("Content-Encoding", h.ContentEncoding)
+	}
+	if h.ContentLanguage != "" {
+		header.Set("Content-Language", h.ContentLanguage)
+	}
+	if h.ContentType != "" {
+		header.Set("Content-Type", h.ContentType)
+	}
+	if !h.Expires.IsZero() {
+		header.Set("Expires", h.Expires.UTC().Format(http.TimeFormat))
+	}
+	return header
+}
+
+// PutObjectWithHeaders - creates an object storing the standard headers
+// in headers along with it, they are sent with the single PUT or with
+// the request initiating the multipart upload. Headers take precedence
+// over the same keys in metaData.
+func (c Client) PutObjectWithHeaders(bucketName, objectName string, reader io.Reader, metaData map[string][]string, headers ObjectHeaders, progress io.Reader) (n int64, err error) {
+	header := headers.header()
+
+	// Do not modify the metadata map provided by the caller.
+	headerMetaData := make(map[string][]string)
+	for k, v := range metaData {
+		if _, ok := header[http.CanonicalHeaderKey(k)]; ok {
+			continue
+		}
+		headerMetaData[k] = v
+	}
+	for k, v := range header {
+		headerMetaData[k] = v
+	}
+	return c.PutObjectWithMetadata(bucketName, objectName, reader, headerMetaData, progress)
+}
@@ -0,0 +1,91 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests standard headers are sent when creating objects.
+func TestPutObjectWithHeaders(t *testing.T) {
+	received := make(map[string]http.Header)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		received[r.Method] = r.Header
+		if r.Method == "POST" {
+			w.Write([]byte(`<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload</UploadId></InitiateMultipartUploadResult>`))
+			return
+		}
+		w.Header().Set("ETag", "\"etag\"")
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	expires := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	headers := ObjectHeaders{
+		CacheControl:       "max-age=3600",
+		ContentDisposition: "attachment; filename=\"report.pdf\"",
+		ContentEncoding:    "gzip",
+		ContentLanguage:    "en",
+		ContentType:        "application/pdf",
+		Expires:            expires,
+	}
+	metaData := map[string][]string{
+		"cache-control":   {"no-cache"},
+		"X-Amz-Meta-Kind": {"report"},
+	}
+	if _, err = clnt.PutObjectWithHeaders("bucket", "object", strings.NewReader("data"), metaData, headers, nil); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = clnt.initiateMultipartUpload("bucket", "object", headers.header()); err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	expected := map[string]string{
+		"Cache-Control":       "max-age=3600",
+		"Content-Disposition": "attachment; filename=\"report.pdf\"",
+		"Content-Encoding":    "gzip",
+		"Content-Language":    "en",
+		"Content-Type":        "application/pdf",
+		"Expires":             "Wed, 02 Jan 2030 03:04:05 GMT",
+	}
+	for _, method := range []string{"PUT", "POST"} {
+		for k, v := range expected {
+			if got := received[method].Get(k); got != v {
+				t.Errorf("%s: expected %s %q, got %q", method, k, v, got)
+			}
+		}
+	}
+	if got := received["PUT"].Get("X-Amz-Meta-Kind"); got != "report" {
+		t.Errorf("Expected user metadata to be kept, got %q", got)
+	}
+	if len(metaData) != 2 || metaData["cache-control"][0] != "no-cache" {
+		t.Errorf("Metadata of the caller was modified: %v", metaData)
+	}
+}
@@ -199,7 +199,7 @@ func (c Client) putObjectMultipartStream(bucketName, objectName string, reader i
 	for partNumber <= totalPartsCount {
 		// Choose hash algorithms to be calculated by hashCopyN, avoid sha256
 		// with non-v4 signature request or HTTPS connection
-		hashAlgos, hashSums := c.hashMaterials()
+		hashAlgos, hashSums := c.hashMaterialsFor(metaData)
 
 		// Calculates hash sums while copying partSize bytes into tmpBuffer.
 		prtSize, rErr := hashCopyN(hashAlgos, hashSums, tmpBuffer, reader, partSize)
@@ -301,7 +301,7 @@ func (c Client) initiateMultipartUpload(bucketName, objectName string, metaData
 	}
 
 	// Set a default content-type header if the latter is not provided
-	if v, ok := metaData["Content-Type"]; !ok || len(v) == 0 {
+	if customHeader.Get("Content-Type") == "" {
 		customHeader.Set("Content-Type", "application/octet-stream")
 	}
 
@@ -392,6 +392,81 @@ func (c Client) uploadPart(bucketName, objectName, uploadID string, reader io.Re
 	return objPart, nil
 }
 
+// uploadPartCopy - Uploads a part in a multipart upload by copying a
+// byte range of an existing object server side. Range is ignored if
+// length is -1 and the entire source object is copied.
+func (c Client) uploadPartCopy(bucketName, objectName, uploadID string, partNumber int, objectSource string, startOffset, length int64, cpCond CopyConditions) (ObjectPart, error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return ObjectPart{}, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return ObjectPart{}, err
+	}
+	if length > maxPartSize {
+		return ObjectPart{}, ErrEntityTooLarge(length, maxPartSize, bucketName, objectName)
+	}
+	if partNumber <= 0 {
+		return ObjectPart{}, ErrInvalidArgument("Part number cannot be negative or equal to zero.")
+	}
+	if uploadID == "" {
+		return ObjectPart{}, ErrInvalidArgument("UploadID cannot be empty.")
+	}
+	if objectSource == "" {
+		return ObjectPart{}, ErrInvalidArgument("Object source cannot be empty.")
+	}
+
+	// Get resources properly escaped and lined up before using them in http request.
+	urlValues := make(url.Values)
+	// Set part number.
+	urlValues.Set("partNumber", strconv.Itoa(partNumber))
+	// Set upload id.
+	urlValues.Set("uploadId", uploadID)
+
+	// Set copy conditions, source and the range to be copied.
+	customHeader := make(http.Header)
+	for _, cond := range cpCond.conditions {
+		customHeader.Set(cond.key, cond.value)
+	}
+	customHeader.Set("x-amz-copy-source", s3utils.EncodePath(objectSource))
+	if length > -1 {
+		customHeader.Set("x-amz-copy-source-range", fmt.Sprintf("bytes=%d-%d", startOffset, startOffset+length-1))
+	}
+
+	// Execute PUT on each part.
+	resp, err := c.executeMethod("PUT", requestMetadata{
+		bucketName:         bucketName,
+		objectName:         objectName,
+		queryValues:        urlValues,
+		customHeader:       customHeader,
+		contentSHA256Bytes: emptySHA256,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return ObjectPart{}, err
+	}
+	if resp != nil {
+		if resp.StatusCode != http.StatusOK {
+			return ObjectPart{}, httpRespToErrorResponse(resp, bucketName, objectName)
+		}
+	}
+
+	// Decode copy part response on success.
+	cpObjRes := copyObjectResult{}
+	if err = xmlDecoder(resp.Body, &cpObjRes); err != nil {
+		return ObjectPart{}, err
+	}
+
+	// Once successfully copied, return completed part.
+	objPart := ObjectPart{}
+	objPart.Size = length
+	objPart.PartNumber = partNumber
+	// Trim off the odd double quotes from ETag in the beginning and end.
+	objPart.ETag = strings.TrimPrefix(cpObjRes.ETag, "\"")
+	objPart.ETag = strings.TrimSuffix(objPart.ETag, "\"")
+	return objPart, nil
+}
+
 // completeMultipartUpload - Completes a multipart upload by assembling previously uploaded parts.
 func (c Client) completeMultipartUpload(bucketName, objectName, uploadID string, complete completeMultipartUpload) (completeMultipartUploadResult, error) {
 	// Input validation.
@@ -81,6 +81,9 @@ func (c Client) PutObjectWithMetadata(bucketName, objectName string, reader io.R
 	if size > int64(maxMultipartPutObjectSize) {
 		return 0, ErrEntityTooLarge(size, maxMultipartPutObjectSize, bucketName, objectName)
 	}
+	if c.isDryRun {
+		return c.dryRunPutObject(bucketName, objectName, size)
+	}
 
 	// NOTE: Google Cloud Storage does not implement Amazon S3 Compatible multipart PUT.
 	if s3utils.IsGoogleEndpoint(c.endpointURL) {
@@ -157,9 +160,15 @@ func (c Client) PutObjectStreamingWithProgress(bucketName, objectName string, re
 		return 0, ErrEntityTooLarge(size, maxMultipartPutObjectSize, bucketName, objectName)
 	}
 
+	if c.isDryRun {
+		return c.dryRunPutObject(bucketName, objectName, size)
+	}
+
 	// If size cannot be found on a stream, it is not possible
 	// to upload using streaming signature, fall back to multipart.
-	if size < 0 {
+	// Object lock requests need Content-MD5 which the streaming
+	// signature does not provide.
+	if size < 0 || isObjectLockRequest(metadata) {
 		return c.putObjectMultipartStream(bucketName, objectName, reader, size, metadata, progress)
 	}
 
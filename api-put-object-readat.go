@@ -132,7 +132,7 @@ func (c Client) putObjectMultipartFromReadAt(bucketName, objectName string, read
 
 				// Choose the needed hash algorithms to be calculated by hashCopyBuffer.
 				// Sha256 is avoided in non-v4 signature requests or HTTPS connections
-				hashAlgos, hashSums := c.hashMaterials()
+				hashAlgos, hashSums := c.hashMaterialsFor(metaData)
 
 				var prtSize int64
 				var err error
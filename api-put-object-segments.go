@@ -0,0 +1,206 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// ObjectSegment is one piece of an object uploaded with
+// PutObjectFromSegments. Size must be the exact number of bytes
+// Reader will yield.
+type ObjectSegment struct {
+	Reader io.Reader
+	Size   int64
+}
+
+// PutObjectFromSegments creates an object from the logical
+// concatenation of segments, without staging the whole object
+// first.
+//
+//  - For a total size smaller than 64MiB a single atomic Put operation is done.
+//  - For larger sizes a multipart Put operation is done, where a new part
+//    is started at every segment boundary once the current part has
+//    reached the 5MiB minimum. Smaller segments are merged with the
+//    ones following them.
+func (c Client) PutObjectFromSegments(bucketName, objectName string, segments []ObjectSegment, metaData map[string][]string, progress io.Reader) (n int64, err error) {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return 0, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return 0, err
+	}
+	if len(segments) == 0 {
+		return 0, ErrInvalidArgument("Input segments cannot be empty.")
+	}
+
+	var size int64
+	sizes := make([]int64, len(segments))
+	readers := make([]io.Reader, len(segments))
+	for i, segment := range segments {
+		if segment.Reader == nil {
+			return 0, ErrInvalidArgument(fmt.Sprintf("Reader of segment %d is invalid, cannot be nil.", i))
+		}
+		if segment.Size < 0 {
+			return 0, ErrInvalidArgument(fmt.Sprintf("Size of segment %d cannot be negative.", i))
+		}
+		sizes[i] = segment.Size
+		readers[i] = io.LimitReader(segment.Reader, segment.Size)
+		size += segment.Size
+	}
+
+	// Check for largest object size allowed.
+	if size > int64(maxMultipartPutObjectSize) {
+		return 0, ErrEntityTooLarge(size, maxMultipartPutObjectSize, bucketName, objectName)
+	}
+	if c.isDryRun {
+		return c.dryRunPutObject(bucketName, objectName, size)
+	}
+
+	reader := io.MultiReader(readers...)
+
+	// NOTE: Google Cloud Storage does not implement Amazon S3 Compatible multipart PUT.
+	if s3utils.IsGoogleEndpoint(c.endpointURL) {
+		return c.putObjectNoChecksum(bucketName, objectName, reader, size, metaData, progress)
+	}
+
+	// putSmall object.
+	if size < minPartSize {
+		return c.putObjectSingle(bucketName, objectName, reader, size, metaData, progress)
+	}
+
+	_, partSize, _, err := optimalPartInfo(size)
+	if err != nil {
+		return 0, err
+	}
+	return c.putObjectMultipartSegments(bucketName, objectName, reader, size, segmentPartSizes(sizes, partSize), metaData, progress)
+}
+
+// segmentPartSizes maps segment boundaries onto part boundaries.
+// Parts never exceed partSize, and a part is closed at the end of a
+// segment only once it holds at least absMinPartSize bytes. If the
+// resulting plan needs more parts than allowed, segment boundaries
+// are ignored and the data is split into parts of partSize.
+func segmentPartSizes(sizes []int64, partSize int64) []int64 {
+	var parts []int64
+	var current, total int64
+	for _, size := range sizes {
+		total += size
+		for size > 0 {
+			n := partSize - current
+			if n > size {
+				n = size
+			}
+			current += n
+			size -= n
+			if current == partSize {
+				parts = append(parts, current)
+				current = 0
+			}
+		}
+		if current >= absMinPartSize {
+			parts = append(parts, current)
+			current = 0
+		}
+	}
+	if current > 0 {
+		parts = append(parts, current)
+	}
+	if len(parts) <= maxPartsCount {
+		return parts
+	}
+
+	parts = parts[:0]
+	for total > 0 {
+		n := partSize
+		if n > total {
+			n = total
+		}
+		parts = append(parts, n)
+		total -= n
+	}
+	return parts
+}
+
+// putObjectMultipartSegments uploads reader as a multipart upload
+// made of parts of the given sizes.
+func (c Client) putObjectMultipartSegments(bucketName, objectName string, reader io.Reader, size int64, partSizes []int64, metaData map[string][]string, progress io.Reader) (n int64, err error) {
+	// Total data read and written to server. should be equal to 'size' at the end of the call.
+	var totalUploadedSize int64
+
+	// Complete multipart upload.
+	var complMultipartUpload completeMultipartUpload
+
+	// Initiate a new multipart upload.
+	uploadID, err := c.newUploadID(bucketName, objectName, metaData)
+	if err != nil {
+		return 0, err
+	}
+
+	// Initialize a temporary buffer.
+	tmpBuffer := new(bytes.Buffer)
+
+	for i, partSize := range partSizes {
+		partNumber := i + 1
+
+		// Choose hash algorithms to be calculated by hashCopyN, avoid sha256
+		// with non-v4 signature request or HTTPS connection
+		hashAlgos, hashSums := c.hashMaterialsFor(metaData)
+
+		// Calculates hash sums while copying partSize bytes into tmpBuffer.
+		prtSize, rErr := hashCopyN(hashAlgos, hashSums, tmpBuffer, reader, partSize)
+		if rErr != nil && rErr != io.EOF {
+			return totalUploadedSize, rErr
+		}
+		if prtSize != partSize {
+			return totalUploadedSize + prtSize, ErrUnexpectedEOF(totalUploadedSize+prtSize, size, bucketName, objectName)
+		}
+
+		// Proceed to upload the part, progress is updated as the
+		// part is read from tmpBuffer.
+		objPart, err := c.uploadPart(bucketName, objectName, uploadID, newHook(tmpBuffer, progress), partNumber, hashSums["md5"], hashSums["sha256"], prtSize)
+		// Reset the temporary buffer.
+		tmpBuffer.Reset()
+		if err != nil {
+			return totalUploadedSize, err
+		}
+
+		complMultipartUpload.Parts = append(complMultipartUpload.Parts, CompletePart{
+			ETag:       objPart.ETag,
+			PartNumber: objPart.PartNumber,
+		})
+
+		// Save successfully uploaded size.
+		totalUploadedSize += prtSize
+	}
+
+	// Sort all completed parts.
+	sort.Sort(completedParts(complMultipartUpload.Parts))
+	if _, err = c.completeMultipartUpload(bucketName, objectName, uploadID, complMultipartUpload); err != nil {
+		return totalUploadedSize, err
+	}
+
+	// Return final size.
+	return totalUploadedSize, nil
+}
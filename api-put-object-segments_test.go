@@ -0,0 +1,82 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests mapping of segment boundaries onto part boundaries.
+func TestSegmentPartSizes(t *testing.T) {
+	const mib = 1024 * 1024
+	testCases := []struct {
+		sizes    []int64
+		partSize int64
+		parts    []int64
+	}{
+		// Segments larger than the minimum map onto their own parts.
+		{[]int64{10 * mib, 20 * mib, 40 * mib}, minPartSize, []int64{10 * mib, 20 * mib, 40 * mib}},
+		// Small segments are merged with the following ones.
+		{[]int64{2 * mib, 2 * mib, 2 * mib, 70 * mib}, minPartSize, []int64{6 * mib, 64 * mib, 6 * mib}},
+		// Large segments are split at partSize.
+		{[]int64{130 * mib}, minPartSize, []int64{64 * mib, 64 * mib, 2 * mib}},
+		// Remainder of a split segment is merged with the next segment.
+		{[]int64{66 * mib, 10 * mib}, minPartSize, []int64{64 * mib, 12 * mib}},
+		// Empty segments are ignored.
+		{[]int64{0, 70 * mib, 0}, minPartSize, []int64{64 * mib, 6 * mib}},
+	}
+	for i, testCase := range testCases {
+		parts := segmentPartSizes(testCase.sizes, testCase.partSize)
+		if !reflect.DeepEqual(parts, testCase.parts) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.parts, parts)
+		}
+	}
+
+	// Too many boundaries fall back to parts of partSize.
+	sizes := make([]int64, maxPartsCount+1)
+	for i := range sizes {
+		sizes[i] = absMinPartSize
+	}
+	parts := segmentPartSizes(sizes, 8*absMinPartSize)
+	if len(parts) != (maxPartsCount+8)/8 {
+		t.Fatalf("Expected %d parts, got %d", (maxPartsCount+8)/8, len(parts))
+	}
+	if parts[0] != 8*absMinPartSize || parts[len(parts)-1] != absMinPartSize {
+		t.Fatalf("Unexpected part sizes %d and %d", parts[0], parts[len(parts)-1])
+	}
+}
+
+// Tests PutObjectFromSegments input validation.
+func TestPutObjectFromSegmentsInvalid(t *testing.T) {
+	c, err := New("localhost:9000", "access", "secret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := [][]ObjectSegment{
+		nil,
+		{{Reader: nil, Size: 1}},
+		{{Reader: strings.NewReader("a"), Size: -1}},
+	}
+	for i, segments := range testCases {
+		if _, err = c.PutObjectFromSegments("bucket", "object", segments, nil, nil); err == nil {
+			t.Errorf("Test %d: expected to fail", i+1)
+		}
+	}
+}
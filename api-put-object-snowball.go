@@ -0,0 +1,188 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// amzSnowballExtract - MinIO extracts tar archives uploaded with this
+// metadata set to true into the bucket, instead of storing them.
+const amzSnowballExtract = "X-Amz-Meta-Snowball-Auto-Extract"
+
+// SnowballObject is a single object uploaded by PutObjectsSnowball.
+// Size must be the exact number of bytes Content will yield.
+type SnowballObject struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+	Content io.Reader
+}
+
+// PutObjectsSnowball uploads many small objects at once. The objects
+// are packed into a tar archive which is uploaded in a single
+// operation with the MinIO auto extract extension, the server then
+// creates one object per archive entry.
+//
+// Amazon S3 and Google Cloud Storage do not support the extension,
+// objects are uploaded individually for them, or when the server
+// rejects the archive as not implemented. Servers storing the archive
+// as is are detected after the upload, the archive is then removed
+// and the objects are uploaded individually.
+func (c Client) PutObjectsSnowball(bucketName string, objects []SnowballObject) error {
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if err := s3utils.CheckValidObjectName(object.Key); err != nil {
+			return err
+		}
+		if object.Content == nil {
+			return ErrInvalidArgument(fmt.Sprintf("Content of object %s is invalid, cannot be nil.", object.Key))
+		}
+		if object.Size < 0 {
+			return ErrInvalidArgument(fmt.Sprintf("Size of object %s cannot be negative.", object.Key))
+		}
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+	if c.isDryRun {
+		for _, object := range objects {
+			c.dryRun("put object %s/%s of %d bytes in a snowball archive", bucketName, object.Key, object.Size)
+		}
+		return nil
+	}
+
+	if s3utils.IsAmazonEndpoint(c.endpointURL) || s3utils.IsGoogleEndpoint(c.endpointURL) {
+		return c.putObjectsIndividually(bucketName, objects)
+	}
+
+	// Stage the archive in a temporary file, uploading it from a file
+	// allows parts to be retried without keeping the archive in memory.
+	tmpFile, err := newTempFile("snowball$-putobjects")
+	if err != nil {
+		return err
+	}
+	defer tmpFile.Close()
+
+	if err = writeSnowballArchive(tmpFile.File, bucketName, objects); err != nil {
+		return err
+	}
+	if _, err = tmpFile.Seek(0, 0); err != nil {
+		return err
+	}
+
+	archiveName := fmt.Sprintf("snowball-%d.tar", time.Now().UnixNano())
+	_, err = c.PutObjectWithMetadata(bucketName, archiveName, tmpFile.File, map[string][]string{
+		"Content-Type":     {"application/x-tar"},
+		amzSnowballExtract: {"true"},
+	}, nil)
+	if err != nil && ToErrorResponse(err).Code != "NotImplemented" {
+		return err
+	}
+	if err == nil {
+		extracted, err := c.snowballArchiveExtracted(bucketName, archiveName, objects)
+		if err != nil || extracted {
+			return err
+		}
+	}
+	// Object contents have been consumed, upload them from the archive.
+	if _, err = tmpFile.Seek(0, 0); err != nil {
+		return err
+	}
+	return c.putSnowballArchiveIndividually(bucketName, tmpFile.File)
+}
+
+// snowballArchiveExtracted - verifies the uploaded archive was
+// extracted into objects, an archive stored as is is removed.
+func (c Client) snowballArchiveExtracted(bucketName, archiveName string, objects []SnowballObject) (bool, error) {
+	_, stored, err := c.statObjectIfExists(bucketName, archiveName)
+	if err != nil {
+		return false, err
+	}
+	if stored {
+		return false, c.RemoveObject(bucketName, archiveName)
+	}
+	_, extracted, err := c.statObjectIfExists(bucketName, objects[len(objects)-1].Key)
+	return extracted, err
+}
+
+// writeSnowballArchive writes objects as a tar archive to w.
+func writeSnowballArchive(w io.Writer, bucketName string, objects []SnowballObject) error {
+	tw := tar.NewWriter(w)
+	for _, object := range objects {
+		modTime := object.ModTime
+		if modTime.IsZero() {
+			modTime = time.Now()
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     object.Key,
+			Mode:     0600,
+			Size:     object.Size,
+			ModTime:  modTime,
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+		n, err := io.CopyN(tw, object.Content, object.Size)
+		if err == io.EOF {
+			return ErrUnexpectedEOF(n, object.Size, bucketName, object.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// putSnowballArchiveIndividually uploads each entry of the tar
+// archive r with its own request.
+func (c Client) putSnowballArchiveIndividually(bucketName string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		segments := []ObjectSegment{{Reader: tr, Size: hdr.Size}}
+		if _, err = c.PutObjectFromSegments(bucketName, hdr.Name, segments, nil, nil); err != nil {
+			return err
+		}
+	}
+}
+
+// putObjectsIndividually uploads each object with its own request.
+func (c Client) putObjectsIndividually(bucketName string, objects []SnowballObject) error {
+	for _, object := range objects {
+		segments := []ObjectSegment{{Reader: object.Content, Size: object.Size}}
+		if _, err := c.PutObjectFromSegments(bucketName, object.Key, segments, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Ways of snowballServer to handle snowball archives.
+const (
+	snowballExtract = iota
+	snowballNotImplemented
+	snowballStore
+)
+
+// snowballServer records objects uploaded to it, handling snowball
+// archives as mode says.
+type snowballServer struct {
+	mutex    sync.Mutex
+	mode     int
+	archives int
+	objects  map[string]string
+}
+
+func (s *snowballServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	switch r.Method {
+	case "HEAD":
+		data, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		return
+	case "DELETE":
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case "PUT":
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	if r.Header.Get(amzSnowballExtract) == "true" && s.mode != snowballStore {
+		if s.mode == snowballNotImplemented {
+			w.WriteHeader(http.StatusNotImplemented)
+			w.Write([]byte(`<Error><Code>NotImplemented</Code><Message>Not implemented</Message></Error>`))
+			return
+		}
+		s.archives++
+		tr := tar.NewReader(bytes.NewReader(body))
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				break
+			}
+			data, _ := ioutil.ReadAll(tr)
+			s.objects[hdr.Name] = string(data)
+		}
+	} else {
+		s.objects[key] = string(body)
+	}
+	w.Header().Set("ETag", "\"etag\"")
+}
+
+// Tests uploading objects as a snowball archive and individually.
+func TestPutObjectsSnowball(t *testing.T) {
+	contents := map[string]string{
+		"a.txt":     "alpha",
+		"dir/b.txt": "bravo",
+		"empty":     "",
+	}
+	for _, mode := range []int{snowballExtract, snowballNotImplemented, snowballStore} {
+		handler := &snowballServer{mode: mode, objects: make(map[string]string)}
+		server := httptest.NewServer(handler)
+		clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		var objects []SnowballObject
+		for key, data := range contents {
+			objects = append(objects, SnowballObject{Key: key, Size: int64(len(data)), Content: strings.NewReader(data)})
+		}
+		if err = clnt.PutObjectsSnowball("bucket", objects); err != nil {
+			t.Fatal("Error:", err)
+		}
+		server.Close()
+
+		if mode == snowballExtract && handler.archives != 1 || mode != snowballExtract && handler.archives != 0 {
+			t.Errorf("Unexpected number of archives %d extracted in mode %d", handler.archives, mode)
+		}
+		// Archives stored as is are removed.
+		if len(handler.objects) != len(contents) {
+			t.Fatalf("Expected %d objects, got %v", len(contents), handler.objects)
+		}
+		for key, data := range contents {
+			if handler.objects[key] != data {
+				t.Errorf("Expected %q for %s, got %q", data, key, handler.objects[key])
+			}
+		}
+	}
+
+	// Short content is detected before anything is uploaded.
+	clnt, err := New("localhost:9000", "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	var buf bytes.Buffer
+	err = writeSnowballArchive(&buf, "bucket", []SnowballObject{{Key: "short", Size: 10, Content: strings.NewReader("abc")}})
+	if ToErrorResponse(err).Code != "UnexpectedEOF" {
+		t.Fatalf("Expected UnexpectedEOF, got %v", err)
+	}
+	if err = clnt.PutObjectsSnowball("bucket", []SnowballObject{{Key: "", Content: strings.NewReader("")}}); err == nil {
+		t.Fatal("Expected invalid object name to fail")
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// NewObjectWriter returns a writer which uploads everything written
+// to it as objectName. Data is buffered into parts of 64MiB which
+// are uploaded as soon as they are full, Close uploads the remaining
+// data and completes the upload. Objects smaller than 64MiB are
+// uploaded in a single PUT operation on Close.
+//
+// Errors are reported by Write and Close, the object is only created
+// if Close returns nil. The maximum object size is 10000 parts of
+// 64MiB.
+//
+// NOTE: Google Cloud Storage does not implement Amazon S3 Compatible
+// multipart PUT, so objects written there are limited to 64MiB.
+func (c Client) NewObjectWriter(bucketName, objectName string) io.WriteCloser {
+	return c.NewObjectWriterWithMetadata(bucketName, objectName, nil)
+}
+
+// NewObjectWriterWithMetadata is NewObjectWriter with metadata to be
+// set on the object.
+func (c Client) NewObjectWriterWithMetadata(bucketName, objectName string, metaData map[string][]string) io.WriteCloser {
+	w := &objectWriter{
+		c:          c,
+		bucketName: bucketName,
+		objectName: objectName,
+		metaData:   metaData,
+		buf:        new(bytes.Buffer),
+	}
+	// Input validation.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		w.err = err
+	} else if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		w.err = err
+	}
+	return w
+}
+
+// objectWriter implements io.WriteCloser returned by NewObjectWriter.
+type objectWriter struct {
+	c          Client
+	bucketName string
+	objectName string
+	metaData   map[string][]string
+
+	// Data not yet uploaded.
+	buf *bytes.Buffer
+	// Upload ID, set once the first part is uploaded.
+	uploadID string
+	// Parts uploaded so far.
+	parts []CompletePart
+	// Total number of bytes written.
+	size int64
+
+	// First error encountered, returned by all later calls.
+	err    error
+	closed bool
+}
+
+// Write implements io.Writer. Full parts are uploaded before Write
+// returns.
+func (w *objectWriter) Write(p []byte) (n int, err error) {
+	if w.closed {
+		return 0, ErrInvalidArgument("Object writer is already closed.")
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.size += int64(len(p))
+	if w.c.isDryRun {
+		return len(p), nil
+	}
+
+	w.buf.Write(p)
+	for w.buf.Len() >= minPartSize {
+		if err = w.uploadPart(w.buf.Next(minPartSize)); err != nil {
+			w.fail(err)
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Close implements io.Closer, it uploads the remaining data and
+// completes the upload.
+func (w *objectWriter) Close() (err error) {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+	if w.c.isDryRun {
+		_, err = w.c.dryRunPutObject(w.bucketName, w.objectName, w.size)
+		return err
+	}
+
+	// Everything fits in a single part, upload it in one request.
+	if w.uploadID == "" {
+		putObject := w.c.putObjectSingle
+		if s3utils.IsGoogleEndpoint(w.c.endpointURL) {
+			// Do not compute MD5 for Google Cloud Storage.
+			putObject = w.c.putObjectNoChecksum
+		}
+		_, err = putObject(w.bucketName, w.objectName, bytes.NewReader(w.buf.Bytes()), int64(w.buf.Len()), w.metaData, nil)
+		w.buf.Reset()
+		if err != nil {
+			w.err = err
+		}
+		return err
+	}
+
+	// Upload the last part, which may be smaller than the others.
+	if w.buf.Len() > 0 {
+		if err = w.uploadPart(w.buf.Next(w.buf.Len())); err != nil {
+			w.fail(err)
+			return err
+		}
+	}
+	if _, err = w.c.completeMultipartUpload(w.bucketName, w.objectName, w.uploadID, completeMultipartUpload{Parts: w.parts}); err != nil {
+		w.fail(err)
+		return err
+	}
+	return nil
+}
+
+// uploadPart uploads data as the next part, initiating the multipart
+// upload first if necessary.
+func (w *objectWriter) uploadPart(data []byte) error {
+	partNumber := len(w.parts) + 1
+	if partNumber > maxPartsCount {
+		return ErrEntityTooLarge(w.size, maxPartsCount*minPartSize, w.bucketName, w.objectName)
+	}
+	// NOTE: Google Cloud Storage does not implement Amazon S3 Compatible multipart PUT.
+	if s3utils.IsGoogleEndpoint(w.c.endpointURL) {
+		return ErrEntityTooLarge(w.size, minPartSize, w.bucketName, w.objectName)
+	}
+	if w.uploadID == "" {
+		uploadID, err := w.c.newUploadID(w.bucketName, w.objectName, w.metaData)
+		if err != nil {
+			return err
+		}
+		w.uploadID = uploadID
+	}
+
+	// Choose hash algorithms to be calculated by hashCopyN, avoid sha256
+	// with non-v4 signature request or HTTPS connection
+	hashAlgos, hashSums := w.c.hashMaterialsFor(w.metaData)
+	size, err := hashCopyN(hashAlgos, hashSums, ioutil.Discard, bytes.NewReader(data), int64(len(data)))
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	objPart, err := w.c.uploadPart(w.bucketName, w.objectName, w.uploadID, bytes.NewReader(data), partNumber, hashSums["md5"], hashSums["sha256"], size)
+	if err != nil {
+		return err
+	}
+	w.parts = append(w.parts, CompletePart{
+		ETag:       objPart.ETag,
+		PartNumber: objPart.PartNumber,
+	})
+	return nil
+}
+
+// fail saves err and aborts the multipart upload, if any.
+func (w *objectWriter) fail(err error) {
+	w.err = err
+	w.buf.Reset()
+	if w.uploadID != "" {
+		w.c.abortMultipartUpload(w.bucketName, w.objectName, w.uploadID)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// objectWriterServer records the uploads issued by an object writer.
+type objectWriterServer struct {
+	mutex     sync.Mutex
+	puts      []int
+	parts     []int
+	completed bool
+	aborted   bool
+	failParts bool
+}
+
+func (s *objectWriterServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	query := r.URL.Query()
+	body, _ := ioutil.ReadAll(r.Body)
+	_, location := query["location"]
+	switch {
+	case location:
+		w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+	case r.Method == "POST" && r.URL.RawQuery == "uploads=":
+		w.Write([]byte(`<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload-id</UploadId></InitiateMultipartUploadResult>`))
+	case r.Method == "PUT" && query.Get("partNumber") != "":
+		if s.failParts {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		s.parts = append(s.parts, len(body))
+		w.Header().Set("ETag", "\"etag\"")
+	case r.Method == "POST" && query.Get("uploadId") != "":
+		s.completed = true
+		w.Write([]byte(`<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><ETag>"etag-2"</ETag></CompleteMultipartUploadResult>`))
+	case r.Method == "DELETE" && query.Get("uploadId") != "":
+		s.aborted = true
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "PUT":
+		s.puts = append(s.puts, len(body))
+		w.Header().Set("ETag", "\"etag\"")
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// writeChunks writes size bytes to w in chunks of 1MiB.
+func writeChunks(w io.Writer, size int) error {
+	chunk := bytes.Repeat([]byte("m"), 1024*1024)
+	for size > 0 {
+		if size < len(chunk) {
+			chunk = chunk[:size]
+		}
+		n, err := w.Write(chunk)
+		if err != nil {
+			return err
+		}
+		size -= n
+	}
+	return nil
+}
+
+// Tests uploads done by the object writer.
+func TestObjectWriter(t *testing.T) {
+	testCases := []struct {
+		size  int
+		puts  []int
+		parts []int
+	}{
+		// Small objects are uploaded with a single PUT on Close.
+		{10, []int{10}, nil},
+		{0, []int{0}, nil},
+		// Larger objects are uploaded in parts of minPartSize.
+		{minPartSize, nil, []int{minPartSize}},
+		{minPartSize + 10, nil, []int{minPartSize, 10}},
+	}
+	for i, testCase := range testCases {
+		handler := &objectWriterServer{}
+		server := httptest.NewServer(handler)
+		clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		w := clnt.NewObjectWriter("bucket", "object")
+		if err = writeChunks(w, testCase.size); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if err = w.Close(); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		server.Close()
+		if len(handler.puts) != len(testCase.puts) || (len(testCase.puts) > 0 && handler.puts[0] != testCase.puts[0]) {
+			t.Errorf("Test %d: expected PUTs %v, got %v", i+1, testCase.puts, handler.puts)
+		}
+		if len(handler.parts) != len(testCase.parts) {
+			t.Errorf("Test %d: expected parts %v, got %v", i+1, testCase.parts, handler.parts)
+			continue
+		}
+		for j := range testCase.parts {
+			if handler.parts[j] != testCase.parts[j] {
+				t.Errorf("Test %d: expected parts %v, got %v", i+1, testCase.parts, handler.parts)
+			}
+		}
+		if handler.completed != (len(testCase.parts) > 0) {
+			t.Errorf("Test %d: unexpected completion of multipart upload", i+1)
+		}
+		if _, err = w.Write([]byte("data")); err == nil {
+			t.Errorf("Test %d: expected write after close to fail", i+1)
+		}
+	}
+}
+
+// Tests the object writer aborts the upload when a part fails.
+func TestObjectWriterFailure(t *testing.T) {
+	handler := &objectWriterServer{failParts: true}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	w := clnt.NewObjectWriter("bucket", "object")
+	if err = writeChunks(w, minPartSize); err == nil {
+		t.Fatal("Expected write to fail")
+	}
+	if err = w.Close(); err == nil {
+		t.Fatal("Expected close to fail")
+	}
+	if !handler.aborted || handler.completed {
+		t.Fatalf("Expected upload to be aborted, got aborted %v completed %v", handler.aborted, handler.completed)
+	}
+
+	w = clnt.NewObjectWriter("", "object")
+	if _, err = w.Write([]byte("data")); err == nil {
+		t.Fatal("Expected invalid bucket name to fail")
+	}
+}
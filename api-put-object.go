@@ -207,7 +207,7 @@ func (c Client) putObjectSingle(bucketName, objectName string, reader io.Reader,
 
 	// Add the appropriate hash algorithms that need to be calculated by hashCopyN
 	// In case of non-v4 signature request or HTTPS connection, sha256 is not needed.
-	hashAlgos, hashSums := c.hashMaterials()
+	hashAlgos, hashSums := c.hashMaterialsFor(metaData)
 
 	// Initialize a new temporary file.
 	tmpFile, err := newTempFile("single$-putobject-single")
@@ -267,7 +267,7 @@ func (c Client) putObjectDo(bucketName, objectName string, reader io.Reader, md5
 	}
 
 	// If Content-Type is not provided, set the default application/octet-stream one
-	if v, ok := metaData["Content-Type"]; !ok || len(v) == 0 {
+	if customHeader.Get("Content-Type") == "" {
 		customHeader.Set("Content-Type", "application/octet-stream")
 	}
 
@@ -300,6 +300,7 @@ func (c Client) putObjectDo(bucketName, objectName string, reader io.Reader, md5
 	objInfo.ETag = strings.TrimSuffix(objInfo.ETag, "\"")
 	// A success here means data was written to server successfully.
 	objInfo.Size = size
+	setObjInfoLifecycle(&objInfo, resp.Header)
 
 	// Return here.
 	return objInfo, nil
@@ -35,6 +35,9 @@ func (c Client) RemoveBucket(bucketName string) error {
 	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
 		return err
 	}
+	if c.dryRun("remove bucket %s", bucketName) {
+		return nil
+	}
 	// Execute DELETE on bucket.
 	resp, err := c.executeMethod("DELETE", requestMetadata{
 		bucketName:         bucketName,
@@ -58,6 +61,25 @@ func (c Client) RemoveBucket(bucketName string) error {
 
 // RemoveObject remove an object from a bucket.
 func (c Client) RemoveObject(bucketName, objectName string) error {
+	return c.RemoveObjectWithOptions(bucketName, objectName, RemoveObjectOptions{})
+}
+
+// RemoveObjectOptions - options of RemoveObjectWithOptions.
+type RemoveObjectOptions struct {
+	// VersionID removes a specific version of the object instead of
+	// adding a delete marker on versioned buckets.
+	VersionID string
+
+	// GovernanceBypass removes object versions locked in governance
+	// mode, which requires the s3:BypassGovernanceRetention
+	// permission. Versions locked in compliance mode or placed under
+	// legal hold are never removed.
+	GovernanceBypass bool
+}
+
+// RemoveObjectWithOptions remove an object, or a version of it, from a
+// bucket as specified in opts.
+func (c Client) RemoveObjectWithOptions(bucketName, objectName string, opts RemoveObjectOptions) error {
 	// Input validation.
 	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
 		return err
@@ -65,10 +87,25 @@ func (c Client) RemoveObject(bucketName, objectName string) error {
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
 		return err
 	}
+	if c.dryRun("remove object %s/%s%s", bucketName, objectName, opts.describe()) {
+		return nil
+	}
+
+	urlValues := make(url.Values)
+	if opts.VersionID != "" {
+		urlValues.Set("versionId", opts.VersionID)
+	}
+	customHeader := make(http.Header)
+	if opts.GovernanceBypass {
+		customHeader.Set(amzBypassGovernance, "true")
+	}
+
 	// Execute DELETE on objectName.
 	resp, err := c.executeMethod("DELETE", requestMetadata{
 		bucketName:         bucketName,
 		objectName:         objectName,
+		queryValues:        urlValues,
+		customHeader:       customHeader,
 		contentSHA256Bytes: emptySHA256,
 	})
 	defer closeResponse(resp)
@@ -91,14 +128,22 @@ func (c Client) RemoveObject(bucketName, objectName string) error {
 // RemoveObjectError - container of Multi Delete S3 API error
 type RemoveObjectError struct {
 	ObjectName string
+	VersionID  string
 	Err        error
 }
 
+// ObjectVersion - an object to be removed by RemoveObjectsWithOptions,
+// or a specific version of it if VersionID is set.
+type ObjectVersion struct {
+	ObjectName string
+	VersionID  string
+}
+
 // generateRemoveMultiObjects - generate the XML request for remove multi objects request
-func generateRemoveMultiObjectsRequest(objects []string) []byte {
+func generateRemoveMultiObjectsRequest(objects []ObjectVersion) []byte {
 	rmObjects := []deleteObject{}
 	for _, obj := range objects {
-		rmObjects = append(rmObjects, deleteObject{Key: obj})
+		rmObjects = append(rmObjects, deleteObject{Key: obj.ObjectName, VersionID: obj.VersionID})
 	}
 	xmlBytes, _ := xml.Marshal(deleteMultiObjects{Objects: rmObjects, Quiet: true})
 	return xmlBytes
@@ -106,7 +151,7 @@ func generateRemoveMultiObjectsRequest(objects []string) []byte {
 
 // processRemoveMultiObjectsResponse - parse the remove multi objects web service
 // and return the success/failure result status for each object
-func processRemoveMultiObjectsResponse(body io.Reader, objects []string, errorCh chan<- RemoveObjectError) {
+func processRemoveMultiObjectsResponse(body io.Reader, objects []ObjectVersion, errorCh chan<- RemoveObjectError) {
 	// Parse multi delete XML response
 	rmResult := &deleteMultiObjectsResult{}
 	err := xmlDecoder(body, rmResult)
@@ -119,6 +164,7 @@ func processRemoveMultiObjectsResponse(body io.Reader, objects []string, errorCh
 	for _, obj := range rmResult.UnDeletedObjects {
 		errorCh <- RemoveObjectError{
 			ObjectName: obj.Key,
+			VersionID:  obj.VersionID,
 			Err: ErrorResponse{
 				Code:    obj.Code,
 				Message: obj.Message,
@@ -127,10 +173,49 @@ func processRemoveMultiObjectsResponse(body io.Reader, objects []string, errorCh
 	}
 }
 
+// describe - returns the options for dry-run messages.
+func (opts RemoveObjectOptions) describe() string {
+	var description string
+	if opts.VersionID != "" {
+		description += " version " + opts.VersionID
+	}
+	if opts.GovernanceBypass {
+		description += " bypassing governance retention"
+	}
+	return description
+}
+
 // RemoveObjects remove multiples objects from a bucket.
 // The list of objects to remove are received from objectsCh.
 // Remove failures are sent back via error channel.
 func (c Client) RemoveObjects(bucketName string, objectsCh <-chan string) <-chan RemoveObjectError {
+	if objectsCh == nil {
+		return c.RemoveObjectsWithOptions(bucketName, nil, RemoveObjectsOptions{})
+	}
+	versionsCh := make(chan ObjectVersion)
+	go func() {
+		defer close(versionsCh)
+		for objectName := range objectsCh {
+			versionsCh <- ObjectVersion{ObjectName: objectName}
+		}
+	}()
+	return c.RemoveObjectsWithOptions(bucketName, versionsCh, RemoveObjectsOptions{})
+}
+
+// RemoveObjectsOptions - options of RemoveObjectsWithOptions.
+type RemoveObjectsOptions struct {
+	// GovernanceBypass removes object versions locked in governance
+	// mode, which requires the s3:BypassGovernanceRetention
+	// permission. Only takes effect for objects with a VersionID,
+	// removing an object without one adds a delete marker.
+	GovernanceBypass bool
+}
+
+// RemoveObjectsWithOptions remove multiples objects, or versions of
+// them, from a bucket as specified in opts. The list of objects to
+// remove are received from objectsCh. Remove failures are sent back
+// via error channel.
+func (c Client) RemoveObjectsWithOptions(bucketName string, objectsCh <-chan ObjectVersion, opts RemoveObjectsOptions) <-chan RemoveObjectError {
 	errorCh := make(chan RemoveObjectError, 1)
 
 	// Validate if bucket name is valid.
@@ -150,12 +235,30 @@ func (c Client) RemoveObjects(bucketName string, objectsCh <-chan string) <-chan
 		return errorCh
 	}
 
+	if c.isDryRun {
+		go func() {
+			defer close(errorCh)
+			for object := range objectsCh {
+				if err := s3utils.CheckValidObjectName(object.ObjectName); err != nil {
+					errorCh <- RemoveObjectError{ObjectName: object.ObjectName, VersionID: object.VersionID, Err: err}
+					continue
+				}
+				c.dryRun("remove object %s/%s%s", bucketName, object.ObjectName, RemoveObjectOptions{VersionID: object.VersionID, GovernanceBypass: opts.GovernanceBypass}.describe())
+			}
+		}()
+		return errorCh
+	}
+
 	// Generate and call MultiDelete S3 requests based on entries received from objectsCh
 	go func(errorCh chan<- RemoveObjectError) {
 		maxEntries := 1000
 		finish := false
 		urlValues := make(url.Values)
 		urlValues.Set("delete", "")
+		customHeader := make(http.Header)
+		if opts.GovernanceBypass {
+			customHeader.Set(amzBypassGovernance, "true")
+		}
 
 		// Close error channel when Multi delete finishes.
 		defer close(errorCh)
@@ -166,7 +269,7 @@ func (c Client) RemoveObjects(bucketName string, objectsCh <-chan string) <-chan
 				break
 			}
 			count := 0
-			var batch []string
+			var batch []ObjectVersion
 
 			// Try to gather 1000 entries
 			for object := range objectsCh {
@@ -190,6 +293,7 @@ func (c Client) RemoveObjects(bucketName string, objectsCh <-chan string) <-chan
 			resp, err := c.executeMethod("POST", requestMetadata{
 				bucketName:         bucketName,
 				queryValues:        urlValues,
+				customHeader:       customHeader,
 				contentBody:        bytes.NewReader(removeBytes),
 				contentLength:      int64(len(removeBytes)),
 				contentMD5Bytes:    sumMD5(removeBytes),
@@ -197,7 +301,7 @@ func (c Client) RemoveObjects(bucketName string, objectsCh <-chan string) <-chan
 			})
 			if err != nil {
 				for _, b := range batch {
-					errorCh <- RemoveObjectError{ObjectName: b, Err: err}
+					errorCh <- RemoveObjectError{ObjectName: b.ObjectName, VersionID: b.VersionID, Err: err}
 				}
 				continue
 			}
@@ -220,6 +324,9 @@ func (c Client) RemoveIncompleteUpload(bucketName, objectName string) error {
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
 		return err
 	}
+	if c.dryRun("remove incomplete upload %s/%s", bucketName, objectName) {
+		return nil
+	}
 	// Find multipart upload id of the object to be aborted.
 	uploadID, err := c.findUploadID(bucketName, objectName)
 	if err != nil {
@@ -0,0 +1,112 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestOptions - extra headers and query parameters to be sent
+// along with requests, for vendor specific extensions.
+type RequestOptions struct {
+	Header http.Header
+	Query  url.Values
+}
+
+// Headers which are computed by the client and cannot be overridden.
+var reservedRequestHeaders = map[string]bool{
+	"Authorization":        true,
+	"Content-Length":       true,
+	"Host":                 true,
+	"X-Amz-Content-Sha256": true,
+	"X-Amz-Date":           true,
+	"X-Amz-Security-Token": true,
+}
+
+// WithRequestOptions - returns a copy of the client sending the headers
+// and query parameters in opts with every request. Signature V4 signs
+// all of them along with the request, signature V2 only signs X-Amz-*,
+// Content-Type and Content-Md5 headers and query parameters naming S3
+// sub-resources. Headers and query parameters set by an operation
+// itself take precedence. The original client is not
+// modified, extensions for a single call are used as
+//
+//   reqClnt, err := clnt.WithRequestOptions(opts)
+//   if err != nil {
+//           return err
+//   }
+//   objInfo, err := reqClnt.StatObject("mybucket", "myobject")
+func (c Client) WithRequestOptions(opts RequestOptions) (*Client, error) {
+	extraHeader := make(http.Header)
+	for k, v := range c.extraHeader {
+		extraHeader[k] = v
+	}
+	for k, v := range opts.Header {
+		k = http.CanonicalHeaderKey(k)
+		if reservedRequestHeaders[k] {
+			return nil, ErrInvalidArgument("Header " + k + " is computed by the client and cannot be set.")
+		}
+		if len(v) == 0 {
+			continue
+		}
+		extraHeader[k] = v
+	}
+
+	extraQuery := make(url.Values)
+	for k, v := range c.extraQuery {
+		extraQuery[k] = v
+	}
+	for k, v := range opts.Query {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-") {
+			return nil, ErrInvalidArgument("Query parameter " + k + " is reserved for request signing.")
+		}
+		extraQuery[k] = v
+	}
+
+	c.extraHeader = extraHeader
+	c.extraQuery = extraQuery
+	return &c, nil
+}
+
+// addExtraQuery returns queryValues along with the extra query
+// parameters, queryValues is not modified.
+func (c Client) addExtraQuery(queryValues url.Values) url.Values {
+	if len(c.extraQuery) == 0 {
+		return queryValues
+	}
+	values := make(url.Values)
+	for k, v := range c.extraQuery {
+		values[k] = v
+	}
+	for k, v := range queryValues {
+		values[k] = v
+	}
+	return values
+}
+
+// addExtraHeader sets the extra headers on req which are not already
+// set by the operation.
+func (c Client) addExtraHeader(req *http.Request) {
+	for k, v := range c.extraHeader {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = v
+		}
+	}
+}
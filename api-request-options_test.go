@@ -0,0 +1,82 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Tests extra headers and query parameters are sent and signed.
+func TestWithRequestOptions(t *testing.T) {
+	var lastReq *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		lastReq = r
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access-key", "secret-key", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	opts := RequestOptions{
+		Header: http.Header{"x-minio-extension": {"enabled"}},
+		Query:  url.Values{"vendor": {"value"}},
+	}
+	extClnt, err := clnt.WithRequestOptions(opts)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	if _, err = extClnt.BucketExists("bucket"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if lastReq.Header.Get("X-Minio-Extension") != "enabled" {
+		t.Fatalf("Expected extra header, got %v", lastReq.Header)
+	}
+	if lastReq.URL.Query().Get("vendor") != "value" {
+		t.Fatalf("Expected extra query, got %s", lastReq.URL.RawQuery)
+	}
+	if !strings.Contains(lastReq.Header.Get("Authorization"), "x-minio-extension") {
+		t.Fatalf("Expected extra header to be signed, got %s", lastReq.Header.Get("Authorization"))
+	}
+
+	// Original client is not modified.
+	if _, err = clnt.BucketExists("bucket"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if lastReq.Header.Get("X-Minio-Extension") != "" || lastReq.URL.Query().Get("vendor") != "" {
+		t.Fatal("Error: original client must not send extra options")
+	}
+
+	// Reserved headers and query parameters are rejected.
+	if _, err = clnt.WithRequestOptions(RequestOptions{Header: http.Header{"Authorization": {"x"}}}); err == nil {
+		t.Fatal("Error: reserved header must be rejected")
+	}
+	if _, err = clnt.WithRequestOptions(RequestOptions{Query: url.Values{"X-Amz-Signature": {"x"}}}); err == nil {
+		t.Fatal("Error: reserved query parameter must be rejected")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import "time"
+
+// ServerInfoCount - number of buckets or objects of a deployment.
+type ServerInfoCount struct {
+	Count uint64 `json:"count"`
+}
+
+// ServerInfoUsage - storage used by the objects of a deployment.
+type ServerInfoUsage struct {
+	Size uint64 `json:"size"`
+}
+
+// ServerDrive - state and capacity of a drive of a server.
+type ServerDrive struct {
+	Endpoint       string `json:"endpoint"`
+	State          string `json:"state"`
+	Path           string `json:"path,omitempty"`
+	TotalSpace     uint64 `json:"totalspace,omitempty"`
+	UsedSpace      uint64 `json:"usedspace,omitempty"`
+	AvailableSpace uint64 `json:"availspace,omitempty"`
+}
+
+// ServerProperties - state of a single server of a deployment.
+type ServerProperties struct {
+	State    string        `json:"state"`
+	Endpoint string        `json:"endpoint"`
+	Uptime   int64         `json:"uptime"`
+	Version  string        `json:"version"`
+	CommitID string        `json:"commitID"`
+	Drives   []ServerDrive `json:"drives"`
+}
+
+// ServerInfo - information about a MinIO deployment and its servers.
+type ServerInfo struct {
+	Mode         string             `json:"mode"`
+	Region       string             `json:"region"`
+	DeploymentID string             `json:"deploymentID"`
+	Buckets      ServerInfoCount    `json:"buckets"`
+	Objects      ServerInfoCount    `json:"objects"`
+	Usage        ServerInfoUsage    `json:"usage"`
+	Servers      []ServerProperties `json:"servers"`
+}
+
+// BucketUsageInfo - storage used by the objects of a bucket.
+type BucketUsageInfo struct {
+	Size          uint64 `json:"size"`
+	ObjectsCount  uint64 `json:"objectsCount"`
+	VersionsCount uint64 `json:"versionsCount"`
+}
+
+// DataUsageInfo - storage used by a deployment and each of its
+// buckets, as computed by the last scan of the server.
+type DataUsageInfo struct {
+	LastUpdate       time.Time                  `json:"lastUpdate"`
+	ObjectsCount     uint64                     `json:"objectsCount"`
+	ObjectsTotalSize uint64                     `json:"objectsTotalSize"`
+	BucketsCount     uint64                     `json:"bucketsCount"`
+	BucketsUsage     map[string]BucketUsageInfo `json:"bucketsUsageInfo"`
+}
+
+// GetServerInfo - returns information about the MinIO deployment,
+// its servers and drives. This is a MinIO extension available through
+// the admin API, credentials must be allowed to administer the server.
+func (c Client) GetServerInfo() (ServerInfo, error) {
+	var info ServerInfo
+	if err := c.adminGetJSON("info", nil, "", &info); err != nil {
+		return ServerInfo{}, err
+	}
+	return info, nil
+}
+
+// GetDataUsageInfo - returns the storage usage of the MinIO
+// deployment and of each bucket. Usage is computed periodically by
+// the server, LastUpdate reports when it was computed. This is a
+// MinIO extension available through the admin API.
+func (c Client) GetDataUsageInfo() (DataUsageInfo, error) {
+	var info DataUsageInfo
+	if err := c.adminGetJSON("datausageinfo", nil, "", &info); err != nil {
+		return DataUsageInfo{}, err
+	}
+	return info, nil
+}
@@ -0,0 +1,65 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests decoding of server information and data usage responses.
+func TestServerInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/info":
+			w.Write([]byte(`{"mode":"online","region":"us-east-1","deploymentID":"id","buckets":{"count":2},"objects":{"count":10},"usage":{"size":1024},
+"servers":[{"state":"online","endpoint":"node1:9000","uptime":3600,"version":"v1","commitID":"abc","drives":[{"endpoint":"/data1","state":"ok","totalspace":100,"usedspace":40,"availspace":60}]}]}`))
+		case "/minio/admin/v3/datausageinfo":
+			w.Write([]byte(`{"lastUpdate":"2017-06-01T10:00:00Z","objectsCount":10,"objectsTotalSize":1024,"bucketsCount":2,
+"bucketsUsageInfo":{"a":{"size":1000,"objectsCount":9},"b":{"size":24,"objectsCount":1}}}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"Code":"AccessDenied","Message":"Access Denied."}`))
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	info, err := clnt.GetServerInfo()
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if info.Mode != "online" || info.Buckets.Count != 2 || info.Usage.Size != 1024 || len(info.Servers) != 1 {
+		t.Fatalf("Unexpected server info %#v", info)
+	}
+	if drives := info.Servers[0].Drives; len(drives) != 1 || drives[0].AvailableSpace != 60 {
+		t.Fatalf("Unexpected drives %#v", drives)
+	}
+
+	usage, err := clnt.GetDataUsageInfo()
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if usage.BucketsCount != 2 || usage.BucketsUsage["a"].Size != 1000 || usage.BucketsUsage["b"].ObjectsCount != 1 || usage.LastUpdate.Year() != 2017 {
+		t.Fatalf("Unexpected data usage %#v", usage)
+	}
+}
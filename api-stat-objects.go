@@ -0,0 +1,95 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"sync"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// StatObjects - stats the objects received from objectsCh in bucket
+// with up to concurrency requests in flight, 0 for the default. The
+// info of every object is sent on the returned channel as it arrives,
+// in no particular order; failures are reported in its Err field with
+// Key set to the object name. The channel is closed once objectsCh is
+// closed and drained, or when doneCh is closed.
+func (c Client) StatObjects(bucketName string, objectsCh <-chan string, concurrency int, doneCh <-chan struct{}) <-chan ObjectInfo {
+	statCh := make(chan ObjectInfo, 1)
+
+	// Validate if bucket name is valid.
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		defer close(statCh)
+		statCh <- ObjectInfo{
+			Err: err,
+		}
+		return statCh
+	}
+	// Validate objects channel to be properly allocated.
+	if objectsCh == nil {
+		defer close(statCh)
+		statCh <- ObjectInfo{
+			Err: ErrInvalidArgument("Objects channel cannot be nil"),
+		}
+		return statCh
+	}
+	if concurrency < 0 {
+		defer close(statCh)
+		statCh <- ObjectInfo{
+			Err: ErrInvalidArgument("Concurrency cannot be negative"),
+		}
+		return statCh
+	}
+	if concurrency == 0 {
+		concurrency = totalWorkers
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var objectName string
+				var ok bool
+				select {
+				case objectName, ok = <-objectsCh:
+					if !ok {
+						return
+					}
+				case <-doneCh:
+					return
+				}
+				objInfo, err := c.StatObject(bucketName, objectName)
+				if err != nil {
+					objInfo = ObjectInfo{Key: objectName, Err: err}
+				}
+				select {
+				case statCh <- objInfo:
+				case <-doneCh:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(statCh)
+	}()
+	return statCh
+}
@@ -0,0 +1,103 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Tests objects are stated with bounded concurrency.
+func TestStatObjects(t *testing.T) {
+	var mutex sync.Mutex
+	var inFlight, maxInFlight int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		mutex.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mutex.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+
+		if strings.HasPrefix(r.URL.Path, "/bucket/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Content-Length", "5")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	clnt.SetRetryPolicy(&countingRetryPolicy{})
+
+	objectsCh := make(chan string)
+	go func() {
+		defer close(objectsCh)
+		for i := 0; i < 20; i++ {
+			objectsCh <- fmt.Sprintf("object-%d", i)
+		}
+		objectsCh <- "missing"
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	found := make(map[string]bool)
+	for objInfo := range clnt.StatObjects("bucket", objectsCh, 4, doneCh) {
+		if objInfo.Key == "missing" {
+			if ToErrorResponse(objInfo.Err).Code != "NoSuchKey" {
+				t.Errorf("Expected NoSuchKey, got %v", objInfo.Err)
+			}
+			continue
+		}
+		if objInfo.Err != nil || objInfo.Size != 5 {
+			t.Errorf("Unexpected info of %s: %+v", objInfo.Key, objInfo)
+		}
+		found[objInfo.Key] = true
+	}
+	if len(found) != 20 {
+		t.Errorf("Expected 20 objects, got %d", len(found))
+	}
+	if maxInFlight > 4 || maxInFlight < 2 {
+		t.Errorf("Expected up to 4 requests in flight, got %d", maxInFlight)
+	}
+
+	// A nil channel is rejected.
+	for objInfo := range clnt.StatObjects("bucket", nil, 0, doneCh) {
+		if ToErrorResponse(objInfo.Err).Code != "InvalidArgument" {
+			t.Errorf("Expected InvalidArgument, got %v", objInfo.Err)
+		}
+	}
+}
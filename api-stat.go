@@ -46,7 +46,13 @@ func (c Client) BucketExists(bucketName string) (bool, error) {
 	}
 	if resp != nil {
 		if resp.StatusCode != http.StatusOK {
-			return false, httpRespToErrorResponse(resp, bucketName, "")
+			err = httpRespToErrorResponse(resp, bucketName, "")
+			// Missing buckets are reported here when the bucket
+			// location did not have to be looked up.
+			if ToErrorResponse(err).Code == "NoSuchBucket" {
+				return false, nil
+			}
+			return false, err
 		}
 	}
 	return true, nil
@@ -173,12 +179,91 @@ func (c Client) statObject(bucketName, objectName string, reqHeaders RequestHead
 	metadata := extractObjMetadata(resp.Header)
 
 	// Save object metadata info.
-	return ObjectInfo{
+	objInfo := ObjectInfo{
 		ETag:         md5sum,
 		Key:          objectName,
 		Size:         size,
 		LastModified: date,
 		ContentType:  contentType,
 		Metadata:     metadata,
-	}, nil
+	}
+	setObjInfoHeaders(&objInfo, resp.Header)
+	return objInfo, nil
+}
+
+// Prefix of user defined metadata header keys.
+const amzMetaPrefix = "X-Amz-Meta-"
+
+// setObjInfoHeaders populates the user metadata and the standard
+// response headers of an object into objInfo.
+func setObjInfoHeaders(objInfo *ObjectInfo, header http.Header) {
+	for k, v := range header {
+		if !strings.HasPrefix(http.CanonicalHeaderKey(k), amzMetaPrefix) || len(v) == 0 {
+			continue
+		}
+		if objInfo.UserMetadata == nil {
+			objInfo.UserMetadata = make(map[string]string)
+		}
+		objInfo.UserMetadata[http.CanonicalHeaderKey(k)[len(amzMetaPrefix):]] = v[0]
+	}
+	objInfo.ContentEncoding = header.Get("Content-Encoding")
+	objInfo.ContentDisposition = header.Get("Content-Disposition")
+	objInfo.ContentLanguage = header.Get("Content-Language")
+	objInfo.CacheControl = header.Get("Cache-Control")
+	// Invalid Expires values such as "0" mean already expired,
+	// leave the zero time in such cases.
+	if expires, err := time.Parse(http.TimeFormat, header.Get("Expires")); err == nil {
+		objInfo.Expires = expires
+	}
+	objInfo.VersionID = header.Get("x-amz-version-id")
+	setObjInfoLifecycle(objInfo, header)
+}
+
+// Replication statuses of objects.
+const (
+	// Replication of the object is in progress.
+	ReplicationStatusPending = "PENDING"
+	// The object was replicated.
+	ReplicationStatusCompleted = "COMPLETED"
+	// Replication of the object failed.
+	ReplicationStatusFailed = "FAILED"
+	// The object is a replica of an object of another bucket.
+	ReplicationStatusReplica = "REPLICA"
+)
+
+// setObjInfoLifecycle populates the lifecycle expiration and the
+// replication status of an object into objInfo.
+func setObjInfoLifecycle(objInfo *ObjectInfo, header http.Header) {
+	objInfo.Expiration = header.Get("x-amz-expiration")
+	objInfo.ExpirationTime, objInfo.ExpirationRuleID = parseExpiration(objInfo.Expiration)
+	objInfo.ReplicationStatus = header.Get("x-amz-replication-status")
+}
+
+// parseExpiration - parses the date and the rule ID of an expiration
+// of the form `expiry-date="Fri, 23 Dec 2012 00:00:00 GMT", rule-id="id"`.
+// Missing or invalid values are left empty.
+func parseExpiration(expiration string) (expiry time.Time, ruleID string) {
+	for {
+		// Values are quoted, the date holds a comma itself.
+		expiration = strings.TrimLeft(expiration, ", ")
+		i := strings.Index(expiration, `="`)
+		if i < 0 {
+			return expiry, ruleID
+		}
+		key, rest := expiration[:i], expiration[i+2:]
+		j := strings.Index(rest, `"`)
+		if j < 0 {
+			return expiry, ruleID
+		}
+		value := rest[:j]
+		expiration = rest[j+1:]
+		switch key {
+		case "expiry-date":
+			if t, err := time.Parse(http.TimeFormat, value); err == nil {
+				expiry = t
+			}
+		case "rule-id":
+			ruleID = value
+		}
+	}
 }
@@ -0,0 +1,187 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ClientStats - snapshot of the requests made by a client since it
+// was created.
+type ClientStats struct {
+	// Requests sent by operation, named by the HTTP method and what
+	// it is applied to, e.g. "GET object" or "PUT object?uploadId".
+	Requests map[string]int64
+	// Bytes of request bodies sent and response bodies read.
+	BytesUploaded   int64
+	BytesDownloaded int64
+	// Requests sent again after a failure.
+	Retries int64
+	// Failed operations by error code.
+	Errors map[string]int64
+	// Failed operations without a response.
+	NetworkErrors int64
+}
+
+// clientStats - counters of a client, shared by all its copies.
+type clientStats struct {
+	sync.Mutex
+	stats ClientStats
+}
+
+func newClientStats() *clientStats {
+	return &clientStats{
+		stats: ClientStats{
+			Requests: make(map[string]int64),
+			Errors:   make(map[string]int64),
+		},
+	}
+}
+
+// Stats - returns a snapshot of the requests made by the client,
+// later requests do not change it.
+func (c Client) Stats() ClientStats {
+	stats := ClientStats{
+		Requests: make(map[string]int64),
+		Errors:   make(map[string]int64),
+	}
+	if c.stats == nil {
+		return stats
+	}
+	c.stats.Lock()
+	defer c.stats.Unlock()
+	for operation, count := range c.stats.stats.Requests {
+		stats.Requests[operation] = count
+	}
+	for code, count := range c.stats.stats.Errors {
+		stats.Errors[code] = count
+	}
+	stats.BytesUploaded = c.stats.stats.BytesUploaded
+	stats.BytesDownloaded = c.stats.stats.BytesDownloaded
+	stats.Retries = c.stats.stats.Retries
+	stats.NetworkErrors = c.stats.stats.NetworkErrors
+	return stats
+}
+
+// Query parameters naming the sub-resource of an operation.
+var statsSubResources = map[string]bool{
+	"acl":          true,
+	"cors":         true,
+	"delete":       true,
+	"encryption":   true,
+	"lifecycle":    true,
+	"list-type":    true,
+	"location":     true,
+	"logging":      true,
+	"notification": true,
+	"policy":       true,
+	"replication":  true,
+	"tagging":      true,
+	"uploadId":     true,
+	"uploads":      true,
+	"versioning":   true,
+	"versions":     true,
+	"website":      true,
+}
+
+// statsOperation - names the operation of a request in the stats.
+func statsOperation(method string, metadata requestMetadata) string {
+	operation := method + " service"
+	switch {
+	case metadata.adminPath != "":
+		operation = method + " admin"
+	case metadata.objectName != "":
+		operation = method + " object"
+	case metadata.bucketName != "":
+		operation = method + " bucket"
+	}
+	var subResources []string
+	for key := range metadata.queryValues {
+		if statsSubResources[key] {
+			subResources = append(subResources, key)
+		}
+	}
+	if len(subResources) == 0 {
+		return operation
+	}
+	sort.Strings(subResources)
+	return operation + "?" + strings.Join(subResources, "&")
+}
+
+// requested - counts a request answered by the server.
+func (s *clientStats) requested(operation string, contentLength int64) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.stats.Requests[operation]++
+	if contentLength > 0 {
+		s.stats.BytesUploaded += contentLength
+	}
+}
+
+// retried - counts a request sent again.
+func (s *clientStats) retried() {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	s.stats.Retries++
+	s.Unlock()
+}
+
+// failed - counts an operation failed with err.
+func (s *clientStats) failed(err error) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	if code := ToErrorResponse(err).Code; code != "" {
+		s.stats.Errors[code]++
+	} else {
+		s.stats.NetworkErrors++
+	}
+}
+
+// countDownload - returns body counting the bytes read from it.
+func (s *clientStats) countDownload(body io.ReadCloser) io.ReadCloser {
+	if s == nil || body == nil {
+		return body
+	}
+	return &countingBody{ReadCloser: body, stats: s}
+}
+
+type countingBody struct {
+	io.ReadCloser
+	stats *clientStats
+}
+
+func (b *countingBody) Read(p []byte) (n int, err error) {
+	n, err = b.ReadCloser.Read(p)
+	if n > 0 {
+		b.stats.Lock()
+		b.stats.stats.BytesDownloaded += int64(n)
+		b.stats.Unlock()
+	}
+	return n, err
+}
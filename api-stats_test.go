@@ -0,0 +1,132 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Tests requests, bytes moved, retries and errors are counted.
+func TestClientStats(t *testing.T) {
+	var failures int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		ioutil.ReadAll(r.Body)
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/bucket/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == "GET" {
+			w.Header().Set("Content-Length", "11")
+			w.Write([]byte("hello world"))
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	clnt.SetRetryPolicy(&countingRetryPolicy{maxRetry: 3})
+
+	failures = 2
+	if _, err = clnt.PutObject("bucket", "object", bytes.NewReader([]byte("hello")), "text/plain"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	snapshot := clnt.Stats()
+
+	object, err := clnt.GetObject("bucket", "object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = ioutil.ReadAll(object); err != nil {
+		t.Fatal("Error:", err)
+	}
+	object.Close()
+	clnt.SetRetryPolicy(&countingRetryPolicy{})
+	if _, err = clnt.StatObject("bucket", "missing"); err == nil {
+		t.Fatal("Error: expected the object not to exist")
+	}
+
+	stats := clnt.Stats()
+	if stats.Requests["PUT object"] != 3 || stats.Requests["GET object"] != 1 || stats.Requests["HEAD object"] != 1 {
+		t.Errorf("Unexpected requests %v", stats.Requests)
+	}
+	if stats.BytesUploaded != 15 || stats.BytesDownloaded != 11 {
+		t.Errorf("Expected 15 bytes uploaded and 11 downloaded, got %d and %d", stats.BytesUploaded, stats.BytesDownloaded)
+	}
+	if stats.Retries != 2 || stats.Errors["NoSuchKey"] != 1 || len(stats.Errors) != 1 || stats.NetworkErrors != 0 {
+		t.Errorf("Unexpected retries %d and errors %v, %d", stats.Retries, stats.Errors, stats.NetworkErrors)
+	}
+
+	// Snapshots do not change.
+	if snapshot.Requests["GET object"] != 0 || len(snapshot.Errors) != 0 {
+		t.Errorf("Snapshot changed to %v, %v", snapshot.Requests, snapshot.Errors)
+	}
+	stats.Requests["GET object"] = 10
+	if clnt.Stats().Requests["GET object"] != 1 {
+		t.Error("Snapshot changes the stats of the client")
+	}
+
+	// Unreachable endpoints are counted apart.
+	server.Close()
+	if _, err = clnt.StatObject("bucket", "object"); err == nil {
+		t.Fatal("Error: expected the server to be unreachable")
+	}
+	if stats = clnt.Stats(); stats.NetworkErrors != 1 {
+		t.Errorf("Expected 1 network error, got %d", stats.NetworkErrors)
+	}
+}
+
+// Tests operations are named after the method and sub-resources.
+func TestStatsOperation(t *testing.T) {
+	testCases := []struct {
+		method   string
+		metadata requestMetadata
+		expected string
+	}{
+		{"GET", requestMetadata{}, "GET service"},
+		{"GET", requestMetadata{bucketName: "bucket", queryValues: url.Values{"location": {""}}}, "GET bucket?location"},
+		{"GET", requestMetadata{bucketName: "bucket", queryValues: url.Values{"list-type": {"2"}, "prefix": {"a"}}}, "GET bucket?list-type"},
+		{"PUT", requestMetadata{bucketName: "bucket", objectName: "object"}, "PUT object"},
+		{"PUT", requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"uploadId": {"id"}, "partNumber": {"1"}}}, "PUT object?uploadId"},
+		{"POST", requestMetadata{bucketName: "bucket", queryValues: url.Values{"delete": {""}}}, "POST bucket?delete"},
+		{"POST", requestMetadata{adminPath: "/v3/info"}, "POST admin"},
+	}
+	for i, testCase := range testCases {
+		if operation := statsOperation(testCase.method, testCase.metadata); operation != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, operation)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"time"
+)
+
+// Default interval between two polls of a waiter.
+const defaultWaitInterval = 5 * time.Second
+
+// ErrWaitTimeout - the awaited state of the bucket or object was not
+// reached within timeout.
+func ErrWaitTimeout(bucketName, objectName string, timeout time.Duration) error {
+	return ErrorResponse{
+		Code:       "WaitTimeout",
+		Message:    fmt.Sprintf("Awaited state was not reached within %s.", timeout),
+		BucketName: bucketName,
+		Key:        objectName,
+		RequestID:  "minio",
+	}
+}
+
+// WaitUntilBucketExists - polls the bucket every interval until it
+// exists, or fails with ErrWaitTimeout after timeout. An interval of
+// 0 polls every 5 seconds, a timeout of 0 waits indefinitely.
+func (c Client) WaitUntilBucketExists(bucketName string, interval, timeout time.Duration) error {
+	return c.waitUntil(bucketName, "", interval, timeout, func() (bool, error) {
+		return c.BucketExists(bucketName)
+	})
+}
+
+// WaitUntilBucketNotExists - polls the bucket every interval until it
+// no longer exists, or fails with ErrWaitTimeout after timeout.
+func (c Client) WaitUntilBucketNotExists(bucketName string, interval, timeout time.Duration) error {
+	return c.waitUntil(bucketName, "", interval, timeout, func() (bool, error) {
+		found, err := c.BucketExists(bucketName)
+		return !found, err
+	})
+}
+
+// WaitUntilObjectExists - polls the object every interval until it
+// exists and returns its info, or fails with ErrWaitTimeout after
+// timeout. An interval of 0 polls every 5 seconds, a timeout of 0
+// waits indefinitely.
+func (c Client) WaitUntilObjectExists(bucketName, objectName string, interval, timeout time.Duration) (ObjectInfo, error) {
+	var objInfo ObjectInfo
+	err := c.waitUntil(bucketName, objectName, interval, timeout, func() (found bool, err error) {
+		objInfo, found, err = c.statObjectIfExists(bucketName, objectName)
+		return found, err
+	})
+	return objInfo, err
+}
+
+// WaitUntilObjectNotExists - polls the object every interval until it
+// no longer exists, or fails with ErrWaitTimeout after timeout.
+func (c Client) WaitUntilObjectNotExists(bucketName, objectName string, interval, timeout time.Duration) error {
+	return c.waitUntil(bucketName, objectName, interval, timeout, func() (bool, error) {
+		_, found, err := c.statObjectIfExists(bucketName, objectName)
+		return !found, err
+	})
+}
+
+// statObjectIfExists - stats the object, a missing object or bucket
+// is not an error.
+func (c Client) statObjectIfExists(bucketName, objectName string) (ObjectInfo, bool, error) {
+	objInfo, err := c.StatObject(bucketName, objectName)
+	if err != nil {
+		switch ToErrorResponse(err).Code {
+		case "NoSuchKey", "NoSuchBucket":
+			return ObjectInfo{}, false, nil
+		}
+		return ObjectInfo{}, false, err
+	}
+	return objInfo, true, nil
+}
+
+// waitUntil - polls condition every interval until it is met, fails
+// or timeout passes.
+func (c Client) waitUntil(bucketName, objectName string, interval, timeout time.Duration, condition func() (bool, error)) error {
+	if interval < 0 {
+		return ErrInvalidArgument("Wait interval cannot be negative.")
+	}
+	if timeout < 0 {
+		return ErrInvalidArgument("Wait timeout cannot be negative.")
+	}
+	if interval == 0 {
+		interval = defaultWaitInterval
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		met, err := condition()
+		if err != nil {
+			return err
+		}
+		if met {
+			return nil
+		}
+		wait := interval
+		if !deadline.IsZero() {
+			remaining := deadline.Sub(time.Now())
+			if remaining <= 0 {
+				return ErrWaitTimeout(bucketName, objectName, timeout)
+			}
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+		time.Sleep(wait)
+	}
+}
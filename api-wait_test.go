@@ -0,0 +1,123 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newWaitServer - returns a server of a bucket and an object which
+// exist from the given request on, or fail with status.
+func newWaitServer(from int, status int) (*httptest.Server, *int) {
+	var mutex sync.Mutex
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		mutex.Lock()
+		requests++
+		exists := from > 0 && requests >= from
+		mutex.Unlock()
+		switch {
+		case status != 0:
+			w.WriteHeader(status)
+		case !exists && strings.Trim(r.URL.Path, "/") == "bucket":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`))
+		case !exists:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Content-Length", "5")
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		}
+	}))
+	return server, &requests
+}
+
+// Tests waiters poll until the awaited state or the timeout.
+func TestWaitUntilObjectExists(t *testing.T) {
+	testCases := []struct {
+		from     int
+		status   int
+		exists   bool
+		errCode  string
+		requests int
+	}{
+		// Object created by the third poll.
+		{3, 0, true, "", 3},
+		// Object never created.
+		{0, 0, false, "WaitTimeout", -1},
+		// Other errors are not waited out.
+		{0, http.StatusForbidden, false, "AccessDenied", 1},
+	}
+	for i, testCase := range testCases {
+		server, requests := newWaitServer(testCase.from, testCase.status)
+		clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		clnt.SetRetryPolicy(&countingRetryPolicy{})
+
+		objInfo, err := clnt.WaitUntilObjectExists("bucket", "object", time.Millisecond, 50*time.Millisecond)
+		server.Close()
+		if ToErrorResponse(err).Code != testCase.errCode {
+			t.Errorf("Test %d: expected %q, got %v", i+1, testCase.errCode, err)
+		}
+		if testCase.exists && (objInfo.ETag != "etag" || objInfo.Size != 5) {
+			t.Errorf("Test %d: unexpected object info %+v", i+1, objInfo)
+		}
+		if testCase.requests > 0 && *requests != testCase.requests {
+			t.Errorf("Test %d: expected %d polls, got %d", i+1, testCase.requests, *requests)
+		}
+	}
+}
+
+// Tests waiting for buckets and for deletion.
+func TestWaitUntilBucketExists(t *testing.T) {
+	server, requests := newWaitServer(2, 0)
+	defer server.Close()
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	if err = clnt.WaitUntilBucketExists("bucket", time.Millisecond, time.Second); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if *requests != 2 {
+		t.Errorf("Expected 2 polls, got %d", *requests)
+	}
+	// Both exist from now on.
+	if err = clnt.WaitUntilBucketNotExists("bucket", time.Millisecond, 10*time.Millisecond); ToErrorResponse(err).Code != "WaitTimeout" {
+		t.Errorf("Expected WaitTimeout, got %v", err)
+	}
+	if err = clnt.WaitUntilObjectNotExists("bucket", "object", time.Millisecond, 10*time.Millisecond); ToErrorResponse(err).Code != "WaitTimeout" {
+		t.Errorf("Expected WaitTimeout, got %v", err)
+	}
+	if err = clnt.WaitUntilBucketExists("bucket", -time.Second, 0); ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
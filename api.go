@@ -68,12 +68,20 @@ type Client struct {
 
 	// Needs allocation.
 	httpClient     *http.Client
-	bucketLocCache *bucketLocationCache
+	bucketLocCache BucketLocationCache
 
 	// Advanced functionality.
 	isTraceEnabled bool
 	traceOutput    io.Writer
 
+	// Dry-run mode, mutating operations are only logged.
+	isDryRun     bool
+	dryRunOutput io.Writer
+
+	// Extra headers and query parameters sent with every request.
+	extraHeader http.Header
+	extraQuery  url.Values
+
 	// S3 specific accelerated endpoint.
 	s3AccelerateEndpoint string
 
@@ -82,6 +90,28 @@ type Client struct {
 
 	// Random seed.
 	random *rand.Rand
+
+	// Endpoint health as last observed by HealthCheck.
+	healthStatus *int32
+
+	// Retry policy of failed requests, nil for the default policy.
+	retryPolicy RetryPolicy
+
+	// Difference in nanoseconds between the server clock and the
+	// local clock, applied to signing times.
+	clockOffset *int64
+
+	// Alternate endpoints of buckets.
+	bucketEndpoints *bucketEndpoints
+
+	// Circuit breaker of endpoints, nil when disabled.
+	circuitBreaker *CircuitBreaker
+
+	// Number of parts copied in parallel, 0 for the default.
+	copyConcurrency int
+
+	// Counters of the requests made, reported by Stats.
+	stats *clientStats
 }
 
 // Global constants.
@@ -224,6 +254,16 @@ func privateNew(endpoint string, creds *credentials.Credentials, secure bool, re
 	// Introduce a new locked random seed.
 	clnt.random = rand.New(&lockedRandSource{src: rand.NewSource(time.Now().UTC().UnixNano())})
 
+	// Endpoint health is unknown until a health check is started.
+	clnt.healthStatus = new(int32)
+	clnt.clockOffset = new(int64)
+
+	// No bucket has an alternate endpoint yet.
+	clnt.bucketEndpoints = newBucketEndpoints()
+
+	// Count requests from the start.
+	clnt.stats = newClientStats()
+
 	// Return.
 	return clnt, nil
 }
@@ -281,6 +321,33 @@ func (c *Client) TraceOff() {
 	c.isTraceEnabled = false
 }
 
+// SetRetryPolicy - sets the policy deciding whether and when failed
+// requests are retried, nil restores the default policy.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// getRetryPolicy - returns the retry policy of the client.
+func (c Client) getRetryPolicy() RetryPolicy {
+	if c.retryPolicy != nil {
+		return c.retryPolicy
+	}
+	defaultPolicy := NewDefaultRetryPolicy()
+	defaultPolicy.random = c.random
+	return defaultPolicy
+}
+
+// SetBucketLocationCache - sets the cache bucket locations are
+// resolved from and saved into, nil restores a cache private to the
+// client. A cache can be shared by clients of the same endpoint, so
+// each bucket location is resolved only once in a process.
+func (c *Client) SetBucketLocationCache(cache BucketLocationCache) {
+	if cache == nil {
+		cache = newBucketLocationCache()
+	}
+	c.bucketLocCache = cache
+}
+
 // SetS3TransferAccelerate - turns s3 accelerated endpoint on or off for all your
 // requests. This feature is only specific to S3 for all other endpoints this
 // function does nothing. To read further details on s3 transfer acceleration
@@ -315,6 +382,16 @@ func (c *Client) hashMaterials() (hashAlgos map[string]hash.Hash, hashSums map[s
 	return hashAlgos, hashSums
 }
 
+// hashMaterialsFor - hash materials for uploading data with metaData,
+// object lock requests always need Content-MD5 set.
+func (c *Client) hashMaterialsFor(metaData map[string][]string) (hashAlgos map[string]hash.Hash, hashSums map[string][]byte) {
+	hashAlgos, hashSums = c.hashMaterials()
+	if _, ok := hashAlgos["md5"]; !ok && isObjectLockRequest(metaData) {
+		hashAlgos["md5"] = md5.New()
+	}
+	return hashAlgos, hashSums
+}
+
 // requestMetadata - is container for all the values to make a request.
 type requestMetadata struct {
 	// If set newRequest presigns the URL.
@@ -327,6 +404,13 @@ type requestMetadata struct {
 	customHeader http.Header
 	expires      int64
 
+	// If set requests are signed at this time instead of now.
+	signTime time.Time
+
+	// If set the request is sent to this MinIO admin API path
+	// instead of a bucket or object, bucketName must be empty.
+	adminPath string
+
 	// Generated by our internal code.
 	bucketLocation     string
 	contentBody        io.Reader
@@ -464,8 +548,9 @@ var successStatus = []int{
 }
 
 // executeMethod - instantiates a given method, and retries the
-// request upon any error up to maxRetries attempts in a binomially
-// delayed manner using a standard back off algorithm.
+// request upon errors as decided by the retry policy of the client,
+// by default up to MaxRetry attempts in a binomially delayed manner
+// using a standard back off algorithm.
 func (c Client) executeMethod(method string, metadata requestMetadata) (res *http.Response, err error) {
 	var isRetryable bool     // Indicates if request can be retried.
 	var bodySeeker io.Seeker // Extracted seeker from io.Reader.
@@ -485,20 +570,19 @@ func (c Client) executeMethod(method string, metadata requestMetadata) (res *htt
 		}
 	}
 
-	// Create a done channel to control 'newRetryTimer' go routine.
-	doneCh := make(chan struct{}, 1)
+	// A consumed body can only be sent again if it can be rewound.
+	canRetry := metadata.contentBody == nil || metadata.contentLength == 0 || isRetryable
 
-	// Indicate to our routine to exit cleanly upon return.
-	defer close(doneCh)
+	retryPolicy := c.getRetryPolicy()
+	// Corrections of the bucket region and of the clock are retried
+	// once, immediately.
+	var regionCorrected, clockCorrected bool
 
-	// Blank indentifier is kept here on purpose since 'range' without
-	// blank identifiers is only supported since go1.4
-	// https://golang.org/doc/go1.4#forrange.
-	for _ = range c.newRetryTimer(MaxRetry, DefaultRetryUnit, DefaultRetryCap, MaxJitter, doneCh) {
-		// Retry executes the following function body if request has an
-		// error until maxRetries have been exhausted, retry attempts are
-		// performed after waiting for a given period of time in a
-		// binomial fashion.
+	operation := statsOperation(method, metadata)
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			c.stats.retried()
+		}
 		if isRetryable {
 			// Seek back to beginning for each attempt.
 			if _, err = bodySeeker.Seek(0, 0); err != nil {
@@ -511,23 +595,37 @@ func (c Client) executeMethod(method string, metadata requestMetadata) (res *htt
 		var req *http.Request
 		req, err = c.newRequest(method, metadata)
 		if err != nil {
-			errResponse := ToErrorResponse(err)
-			if isS3CodeRetryable(errResponse.Code) {
+			if retryPolicy.ShouldRetry(attempt, nil, nil, err) {
+				time.Sleep(retryPolicy.Backoff(attempt))
 				continue // Retry.
 			}
 			return nil, err
 		}
 
+		// Fail fast while the endpoint is deemed unavailable.
+		if c.circuitBreaker != nil {
+			if err = c.circuitBreaker.allow(req.URL.Host); err != nil {
+				c.stats.failed(err)
+				return nil, err
+			}
+		}
+
 		// Initiate the request.
 		res, err = c.do(req)
+		if c.circuitBreaker != nil {
+			c.circuitBreaker.record(req.URL.Host, isCircuitFailure(res, err))
+		}
 		if err != nil {
-			// For supported network errors verify.
-			if isNetErrorRetryable(err) {
+			if canRetry && retryPolicy.ShouldRetry(attempt, req, nil, err) {
+				time.Sleep(retryPolicy.Backoff(attempt))
 				continue // Retry.
 			}
 			// For other errors, return here no need to retry.
+			c.stats.failed(err)
 			return nil, err
 		}
+		c.stats.requested(operation, metadata.contentLength)
+		res.Body = c.stats.countDownload(res.Body)
 
 		// For any known successful http status, return quickly.
 		for _, httpStatus := range successStatus {
@@ -555,30 +653,49 @@ func (c Client) executeMethod(method string, metadata requestMetadata) (res *htt
 		errBodySeeker.Seek(0, 0) // Seek back to starting point.
 		res.Body = ioutil.NopCloser(errBodySeeker)
 
+		if !canRetry {
+			c.stats.failed(errResponse)
+			break
+		}
+
 		// Bucket region if set in error response and the error
 		// code dictates invalid region, we can retry the request
 		// with the new region.
 		//
 		// Additionally we should only retry if bucketLocation and custom
 		// region is empty.
-		if metadata.bucketLocation == "" && c.region == "" {
-			if res.StatusCode == http.StatusBadRequest && errResponse.Region != "" {
-				c.bucketLocCache.Set(metadata.bucketName, errResponse.Region)
-				continue // Retry.
+		if metadata.bucketLocation == "" && c.region == "" && !regionCorrected {
+			if isWrongRegionResponse(res, errResponse) {
+				if errResponse.Region != "" {
+					c.bucketLocCache.Set(metadata.bucketName, errResponse.Region)
+					regionCorrected = true
+					continue // Retry.
+				}
+				// The cached location is stale, resolve it again
+				// next time.
+				c.bucketLocCache.Delete(metadata.bucketName)
 			}
 		}
 
-		// Verify if error response code is retryable.
-		if isS3CodeRetryable(errResponse.Code) {
-			continue // Retry.
+		// Correct the clock from the server date and sign again
+		// if the request time was rejected.
+		if errResponse.Code == "RequestTimeTooSkewed" && metadata.signTime.IsZero() && !clockCorrected {
+			if c.adjustClock(res) {
+				clockCorrected = true
+				continue // Retry.
+			}
 		}
 
-		// Verify if http status code is retryable.
-		if isHTTPStatusRetryable(res.StatusCode) {
+		// Verify if the error is retryable with the retry policy.
+		retry := retryPolicy.ShouldRetry(attempt, req, res, errResponse)
+		errBodySeeker.Seek(0, 0) // The policy may have read the body.
+		if retry {
+			time.Sleep(retryPolicy.Backoff(attempt))
 			continue // Retry.
 		}
 
 		// For all other cases break out of the retry loop.
+		c.stats.failed(errResponse)
 		break
 	}
 	return res, err
@@ -611,10 +728,27 @@ func (c Client) newRequest(method string, metadata requestMetadata) (req *http.R
 	}
 
 	// Construct a new target URL.
-	targetURL, err := c.makeTargetURL(metadata.bucketName, metadata.objectName, location, metadata.queryValues)
+	targetURL, err := c.makeTargetURL(metadata.bucketName, metadata.objectName, location, c.addExtraQuery(metadata.queryValues))
 	if err != nil {
 		return nil, err
 	}
+	if metadata.adminPath != "" {
+		targetURL.Path = metadata.adminPath
+	}
+
+	// Requests to buckets with an alternate endpoint are sent there,
+	// signed either for the alternate or the canonical URL.
+	if endpointURL, signCanonical := c.bucketEndpointURL(method, metadata, targetURL); endpointURL != nil {
+		if !signCanonical {
+			targetURL = endpointURL
+		} else {
+			defer func() {
+				if err == nil {
+					redirectRequest(req, endpointURL)
+				}
+			}()
+		}
+	}
 
 	// Initialize a new HTTP request for the method.
 	req, err = http.NewRequest(method, targetURL.String(), nil)
@@ -646,6 +780,11 @@ func (c Client) newRequest(method string, metadata requestMetadata) (req *http.R
 		signerType = credentials.SignatureAnonymous
 	}
 
+	signTime := metadata.signTime
+	if signTime.IsZero() {
+		signTime = c.now()
+	}
+
 	// Generate presign url if needed, return right here.
 	if metadata.expires != 0 && metadata.presignURL {
 		if signerType.IsAnonymous() {
@@ -653,10 +792,10 @@ func (c Client) newRequest(method string, metadata requestMetadata) (req *http.R
 		}
 		if signerType.IsV2() {
 			// Presign URL with signature v2.
-			req = s3signer.PreSignV2(*req, accessKeyID, secretAccessKey, metadata.expires)
+			req = s3signer.PreSignV2At(*req, accessKeyID, secretAccessKey, metadata.expires, signTime)
 		} else if signerType.IsV4() {
 			// Presign URL with signature v4.
-			req = s3signer.PreSignV4(*req, accessKeyID, secretAccessKey, sessionToken, location, metadata.expires)
+			req = s3signer.PreSignV4At(*req, accessKeyID, secretAccessKey, sessionToken, location, metadata.expires, signTime)
 		}
 		return req, nil
 	}
@@ -668,6 +807,7 @@ func (c Client) newRequest(method string, metadata requestMetadata) (req *http.R
 	for k, v := range metadata.customHeader {
 		req.Header.Set(k, v[0])
 	}
+	c.addExtraHeader(req)
 
 	// Go net/http notoriously closes the request body.
 	// - The request Body, if non-nil, will be closed by the underlying Transport, even on errors.
@@ -699,10 +839,10 @@ func (c Client) newRequest(method string, metadata requestMetadata) (req *http.R
 	switch {
 	case signerType.IsV2():
 		// Add signature version '2' authorization header.
-		req = s3signer.SignV2(*req, accessKeyID, secretAccessKey)
+		req = s3signer.SignV2At(*req, accessKeyID, secretAccessKey, signTime)
 	case signerType.IsStreamingV4() && method == "PUT":
 		req = s3signer.StreamingSignV4(req, accessKeyID,
-			secretAccessKey, sessionToken, location, metadata.contentLength, time.Now().UTC())
+			secretAccessKey, sessionToken, location, metadata.contentLength, signTime)
 	default:
 		// Set sha256 sum for signature calculation only with signature version '4'.
 		shaHeader := unsignedPayload
@@ -712,7 +852,7 @@ func (c Client) newRequest(method string, metadata requestMetadata) (req *http.R
 		req.Header.Set("X-Amz-Content-Sha256", shaHeader)
 
 		// Add signature version '4' authorization header.
-		req = s3signer.SignV4(*req, accessKeyID, secretAccessKey, sessionToken, location)
+		req = s3signer.SignV4At(*req, accessKeyID, secretAccessKey, sessionToken, location, signTime)
 	}
 
 	// Return request.
@@ -2383,3 +2383,64 @@ func TestPutObjectUploadSeekedObject(t *testing.T) {
 		t.Fatal("Error:", err)
 	}
 }
+
+// Tests MoveObject copies the object and removes the source.
+func TestMoveObject(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping functional tests for the short runs")
+	}
+
+	// Instantiate new minio client object.
+	c, err := NewV4(
+		os.Getenv(serverEndpoint),
+		os.Getenv(accessKey),
+		os.Getenv(secretKey),
+		mustParseBool(os.Getenv(enableSecurity)),
+	)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	// Set user agent.
+	c.SetAppInfo("Minio-go-FunctionalTest", "0.1.0")
+
+	// Make a new bucket.
+	bucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "minio-go-test")
+	err = c.MakeBucket(bucketName, "us-east-1")
+	if err != nil {
+		t.Fatal("Error:", err, bucketName)
+	}
+	defer c.RemoveBucket(bucketName)
+
+	buf := bytes.Repeat([]byte("m"), 32*1024)
+	objectName := randString(60, rand.NewSource(time.Now().UnixNano()), "")
+	if _, err = c.PutObject(bucketName, objectName, bytes.NewReader(buf), "binary/octet-stream"); err != nil {
+		t.Fatal("Error:", err, bucketName, objectName)
+	}
+
+	// Moving onto itself should fail.
+	if err = c.MoveObject(bucketName, objectName, bucketName, objectName); err == nil {
+		t.Fatal("Error: moving an object onto itself should fail")
+	}
+
+	if err = c.MoveObject(bucketName, objectName, bucketName, objectName+"-moved"); err != nil {
+		t.Fatal("Error:", err, bucketName, objectName)
+	}
+
+	// Source should be gone.
+	if _, err = c.StatObject(bucketName, objectName); err == nil {
+		t.Fatal("Error: source object should be removed after move")
+	}
+
+	objInfo, err := c.StatObject(bucketName, objectName+"-moved")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if objInfo.Size != int64(len(buf)) {
+		t.Fatalf("Error: size mismatch want %v, got %v", len(buf), objInfo.Size)
+	}
+
+	if err = c.RemoveObject(bucketName, objectName+"-moved"); err != nil {
+		t.Fatal("Error:", err)
+	}
+}
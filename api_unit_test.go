@@ -22,10 +22,12 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/minio-go/pkg/credentials"
 	"github.com/minio/minio-go/pkg/policy"
@@ -356,3 +358,176 @@ func TestMakeTargetURL(t *testing.T) {
 		}
 	}
 }
+
+// Tests metadata preserved on append.
+func TestAppendObjectMetadata(t *testing.T) {
+	objInfo := ObjectInfo{
+		ContentType: "text/plain",
+		Metadata: http.Header{
+			"X-Amz-Meta-Owner":  []string{"logger"},
+			"Content-Encoding":  []string{"gzip"},
+			"X-Amz-Request-Id":  []string{"1234"},
+			"X-Amz-Meta-Source": []string{"app"},
+		},
+	}
+	metaData := appendObjectMetadata(objInfo)
+	if len(metaData) != 3 {
+		t.Fatalf("Expected 3 metadata entries, got %d", len(metaData))
+	}
+	if metaData["Content-Type"][0] != "text/plain" {
+		t.Fatalf("Expected content type 'text/plain', got %s", metaData["Content-Type"][0])
+	}
+	if metaData["X-Amz-Meta-Owner"][0] != "logger" {
+		t.Fatalf("Expected user metadata 'logger', got %s", metaData["X-Amz-Meta-Owner"][0])
+	}
+}
+
+// Tests decoding of get object attributes response.
+func TestDecodeObjectAttributes(t *testing.T) {
+	response := `<?xml version="1.0" encoding="UTF-8"?>
+<GetObjectAttributesResponse>
+  <ETag>"a1b2c3-2"</ETag>
+  <Checksum><ChecksumCRC32>AAAAAA==</ChecksumCRC32></Checksum>
+  <ObjectParts>
+    <TotalPartsCount>2</TotalPartsCount>
+    <PartNumberMarker>0</PartNumberMarker>
+    <NextPartNumberMarker>2</NextPartNumberMarker>
+    <MaxParts>1000</MaxParts>
+    <IsTruncated>false</IsTruncated>
+    <Part><PartNumber>1</PartNumber><Size>5242880</Size><ChecksumCRC32>AQAAAA==</ChecksumCRC32></Part>
+    <Part><PartNumber>2</PartNumber><Size>1024</Size></Part>
+  </ObjectParts>
+  <StorageClass>STANDARD</StorageClass>
+  <ObjectSize>5243904</ObjectSize>
+</GetObjectAttributesResponse>`
+	attrs := ObjectAttributes{}
+	if err := xmlDecoder(strings.NewReader(response), &attrs); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if attrs.ObjectParts.PartsCount != 2 || len(attrs.ObjectParts.Parts) != 2 {
+		t.Fatalf("Expected 2 parts, got %d", len(attrs.ObjectParts.Parts))
+	}
+	if attrs.ObjectParts.Parts[0].Size != 5242880 || attrs.ObjectParts.Parts[0].ChecksumCRC32 != "AQAAAA==" {
+		t.Fatalf("Unexpected first part %#v", attrs.ObjectParts.Parts[0])
+	}
+	if attrs.ObjectSize != 5243904 || attrs.StorageClass != "STANDARD" || attrs.Checksum.ChecksumCRC32 != "AAAAAA==" {
+		t.Fatalf("Unexpected object attributes %#v", attrs)
+	}
+}
+
+// Tests paging of object attributes fails on a part number marker
+// which does not advance.
+func TestGetObjectAttributesStuckMarker(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		requests++
+		w.Write([]byte(`<GetObjectAttributesResponse><ObjectParts><NextPartNumberMarker>1</NextPartNumberMarker><IsTruncated>true</IsTruncated><Part><PartNumber>1</PartNumber><Size>1</Size></Part></ObjectParts></GetObjectAttributesResponse>`))
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = clnt.GetObjectAttributes("bucket", "object"); ToErrorResponse(err).Code != "InvalidResponse" {
+		t.Fatalf("Expected InvalidResponse, got %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("Expected 2 requests, got %d", requests)
+	}
+}
+
+// Tests populating object info from response headers.
+func TestSetObjInfoHeaders(t *testing.T) {
+	header := make(http.Header)
+	header.Set("X-Amz-Meta-Project", "minio")
+	header.Set("x-amz-meta-owner", "ops")
+	header.Set("Content-Encoding", "gzip")
+	header.Set("Content-Disposition", "attachment; filename=\"a.txt\"")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Expires", "Wed, 21 Oct 2026 07:28:00 GMT")
+	header.Set("x-amz-version-id", "v1")
+	header.Set("x-amz-expiration", `expiry-date="Fri, 23 Dec 2026 00:00:00 GMT", rule-id="rule1"`)
+	header.Set("x-amz-replication-status", "COMPLETED")
+
+	var objInfo ObjectInfo
+	setObjInfoHeaders(&objInfo, header)
+	if objInfo.UserMetadata["Project"] != "minio" || objInfo.UserMetadata["Owner"] != "ops" {
+		t.Fatalf("Unexpected user metadata %v", objInfo.UserMetadata)
+	}
+	if objInfo.ContentEncoding != "gzip" || objInfo.CacheControl != "no-cache" {
+		t.Fatalf("Unexpected standard headers %#v", objInfo)
+	}
+	if objInfo.Expires.IsZero() {
+		t.Fatal("Error: Expires was not parsed")
+	}
+	if objInfo.VersionID != "v1" || objInfo.ReplicationStatus != ReplicationStatusCompleted || objInfo.Expiration == "" {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+	if objInfo.ExpirationRuleID != "rule1" || !objInfo.ExpirationTime.Equal(time.Date(2026, 12, 23, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected expiration %v of rule %q", objInfo.ExpirationTime, objInfo.ExpirationRuleID)
+	}
+
+	// Invalid Expires leaves zero time.
+	header.Set("Expires", "0")
+	objInfo = ObjectInfo{}
+	setObjInfoHeaders(&objInfo, header)
+	if !objInfo.Expires.IsZero() {
+		t.Fatal("Error: expected zero Expires for invalid value")
+	}
+}
+
+// Tests parsing lifecycle expirations.
+func TestParseExpiration(t *testing.T) {
+	testCases := []struct {
+		expiration string
+		expiry     time.Time
+		ruleID     string
+	}{
+		{`expiry-date="Fri, 23 Dec 2012 00:00:00 GMT", rule-id="picture-deletion-rule"`, time.Date(2012, 12, 23, 0, 0, 0, 0, time.UTC), "picture-deletion-rule"},
+		{`rule-id="rule, with comma", expiry-date="Fri, 23 Dec 2012 00:00:00 GMT"`, time.Date(2012, 12, 23, 0, 0, 0, 0, time.UTC), "rule, with comma"},
+		{`expiry-date="invalid", rule-id="rule"`, time.Time{}, "rule"},
+		{`expiry-date="Fri, 23 Dec 2012`, time.Time{}, ""},
+		{"", time.Time{}, ""},
+	}
+	for i, testCase := range testCases {
+		expiry, ruleID := parseExpiration(testCase.expiration)
+		if !expiry.Equal(testCase.expiry) || ruleID != testCase.ruleID {
+			t.Errorf("Test %d: expected %v and %q, got %v and %q", i+1, testCase.expiry, testCase.ruleID, expiry, ruleID)
+		}
+	}
+}
+
+// Tests lifecycle expiration and replication status of uploaded
+// objects reach callers of Core.PutObject.
+func TestCorePutObjectLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("x-amz-expiration", `expiry-date="Fri, 23 Dec 2012 00:00:00 GMT", rule-id="rule1"`)
+		w.Header().Set("x-amz-replication-status", ReplicationStatusPending)
+	}))
+	defer server.Close()
+
+	core, err := NewCore(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	objInfo, err := core.PutObject("bucket", "object", 4, strings.NewReader("data"), nil, nil, nil)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if objInfo.ETag != "etag" || objInfo.ExpirationRuleID != "rule1" || objInfo.ReplicationStatus != ReplicationStatusPending {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+	if !objInfo.ExpirationTime.Equal(time.Date(2012, 12, 23, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected expiration %v", objInfo.ExpirationTime)
+	}
+}
@@ -23,12 +23,24 @@ import (
 	"net/url"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/minio/minio-go/pkg/credentials"
 	"github.com/minio/minio-go/pkg/s3signer"
 	"github.com/minio/minio-go/pkg/s3utils"
 )
 
+// BucketLocationCache - holds the locations of buckets, clients resolve
+// bucket locations through it. Implementations are used concurrently.
+type BucketLocationCache interface {
+	// Get - returns the location of bucketName if it is known.
+	Get(bucketName string) (location string, ok bool)
+	// Set - saves the location of bucketName.
+	Set(bucketName string, location string)
+	// Delete - forgets the location of bucketName.
+	Delete(bucketName string)
+}
+
 // bucketLocationCache - Provides simple mechanism to hold bucket
 // locations in memory.
 type bucketLocationCache struct {
@@ -70,6 +82,87 @@ func (r *bucketLocationCache) Delete(bucketName string) {
 	delete(r.items, bucketName)
 }
 
+// expiringLocation - cached location with its expiry time.
+type expiringLocation struct {
+	location string
+	expiry   time.Time
+}
+
+// expiringBucketLocationCache - bucket location cache forgetting
+// locations after a fixed time.
+type expiringBucketLocationCache struct {
+	mutex *sync.RWMutex
+	ttl   time.Duration
+	items map[string]expiringLocation
+}
+
+// NewBucketLocationCache - returns a bucket location cache which can be
+// shared by clients of the same endpoint with SetBucketLocationCache.
+// Locations are forgotten ttl after being saved, a ttl of zero keeps
+// them until they are invalidated. Locations are always invalidated
+// when the server redirects a request to another region.
+func NewBucketLocationCache(ttl time.Duration) BucketLocationCache {
+	return &expiringBucketLocationCache{
+		mutex: &sync.RWMutex{},
+		ttl:   ttl,
+		items: make(map[string]expiringLocation),
+	}
+}
+
+// Get - Returns the location of bucketName unless it expired.
+func (r *expiringBucketLocationCache) Get(bucketName string) (string, bool) {
+	r.mutex.RLock()
+	item, ok := r.items[bucketName]
+	r.mutex.RUnlock()
+	if !ok {
+		return "", false
+	}
+	if r.ttl > 0 && time.Now().After(item.expiry) {
+		r.mutex.Lock()
+		// Keep the location in case it was saved again meanwhile.
+		if current, ok := r.items[bucketName]; ok && current.expiry.Equal(item.expiry) {
+			delete(r.items, bucketName)
+		}
+		r.mutex.Unlock()
+		return "", false
+	}
+	return item.location, true
+}
+
+// Set - Saves the location of bucketName.
+func (r *expiringBucketLocationCache) Set(bucketName string, location string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.items[bucketName] = expiringLocation{
+		location: location,
+		expiry:   time.Now().Add(r.ttl),
+	}
+}
+
+// Delete - Forgets the location of bucketName.
+func (r *expiringBucketLocationCache) Delete(bucketName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.items, bucketName)
+}
+
+// isWrongRegionResponse - tells whether the server rejected a request
+// as it was sent to or signed for another region than the bucket's.
+func isWrongRegionResponse(resp *http.Response, errResp ErrorResponse) bool {
+	switch errResp.Code {
+	case "AuthorizationHeaderMalformed", "PermanentRedirect", "InvalidRegion":
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently:
+		return true
+	case http.StatusBadRequest:
+		// Region is returned only along with region errors.
+		return errResp.Region != ""
+	}
+	return false
+}
+
 // GetBucketLocation - get location for the bucket name from location cache, if not
 // fetch freshly by making a new request.
 func (c Client) GetBucketLocation(bucketName string) (string, error) {
@@ -82,6 +175,18 @@ func (c Client) GetBucketLocation(bucketName string) (string, error) {
 // getBucketLocation - Get location for the bucketName from location map cache, if not
 // fetch freshly by making a new request.
 func (c Client) getBucketLocation(bucketName string) (string, error) {
+	return c.lookupBucketLocation(bucketName, processBucketLocationResponse)
+}
+
+// getBucketLocationStrict - like getBucketLocation, but access denied
+// errors are returned instead of assuming us-east-1.
+func (c Client) getBucketLocationStrict(bucketName string) (string, error) {
+	return c.lookupBucketLocation(bucketName, parseBucketLocationResponse)
+}
+
+// lookupBucketLocation - returns the location of bucketName from the
+// cache, or from the response to a new request processed by process.
+func (c Client) lookupBucketLocation(bucketName string, process func(*http.Response, string) (string, error)) (string, error) {
 	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
 		return "", err
 	}
@@ -121,7 +226,7 @@ func (c Client) getBucketLocation(bucketName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	location, err := processBucketLocationResponse(resp, bucketName)
+	location, err := process(resp, bucketName)
 	if err != nil {
 		return "", err
 	}
@@ -131,17 +236,22 @@ func (c Client) getBucketLocation(bucketName string) (string, error) {
 
 // processes the getBucketLocation http response from the server.
 func processBucketLocationResponse(resp *http.Response, bucketName string) (bucketLocation string, err error) {
+	bucketLocation, err = parseBucketLocationResponse(resp, bucketName)
+	// For access denied error, it could be an anonymous
+	// request. Move forward and let the top level callers
+	// succeed if possible based on their policy.
+	if err != nil && ToErrorResponse(err).Code == "AccessDenied" {
+		return "us-east-1", nil
+	}
+	return bucketLocation, err
+}
+
+// parseBucketLocationResponse - parses the getBucketLocation http
+// response from the server, failures are returned as errors.
+func parseBucketLocationResponse(resp *http.Response, bucketName string) (bucketLocation string, err error) {
 	if resp != nil {
 		if resp.StatusCode != http.StatusOK {
-			err = httpRespToErrorResponse(resp, bucketName, "")
-			errResp := ToErrorResponse(err)
-			// For access denied error, it could be an anonymous
-			// request. Move forward and let the top level callers
-			// succeed if possible based on their policy.
-			if errResp.Code == "AccessDenied" {
-				return "us-east-1", nil
-			}
-			return "", err
+			return "", httpRespToErrorResponse(resp, bucketName, "")
 		}
 	}
 
@@ -178,7 +288,7 @@ func (c Client) getBucketLocationRequest(bucketName string) (*http.Request, erro
 	// Set get bucket location always as path style.
 	targetURL := c.endpointURL
 	targetURL.Path = path.Join(bucketName, "") + "/"
-	targetURL.RawQuery = urlValues.Encode()
+	targetURL.RawQuery = c.addExtraQuery(urlValues).Encode()
 
 	// Get a new HTTP request for the method.
 	req, err := http.NewRequest("GET", targetURL.String(), nil)
@@ -188,6 +298,7 @@ func (c Client) getBucketLocationRequest(bucketName string) (*http.Request, erro
 
 	// Set UserAgent for the request.
 	c.setUserAgent(req)
+	c.addExtraHeader(req)
 
 	// Get credentials from the configured credentials provider.
 	value, err := c.credsProvider.Get()
@@ -223,9 +334,9 @@ func (c Client) getBucketLocationRequest(bucketName string) (*http.Request, erro
 			contentSha256 = hex.EncodeToString(sum256([]byte{}))
 		}
 		req.Header.Set("X-Amz-Content-Sha256", contentSha256)
-		req = s3signer.SignV4(*req, accessKeyID, secretAccessKey, sessionToken, "us-east-1")
+		req = s3signer.SignV4At(*req, accessKeyID, secretAccessKey, sessionToken, "us-east-1", c.now())
 	case signerType.IsV2():
-		req = s3signer.SignV2(*req, accessKeyID, secretAccessKey)
+		req = s3signer.SignV2At(*req, accessKeyID, secretAccessKey, c.now())
 	}
 
 	return req, nil
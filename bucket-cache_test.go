@@ -23,10 +23,13 @@ import (
 	"encoding/xml"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"path"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/minio/minio-go/pkg/credentials"
 	"github.com/minio/minio-go/pkg/s3signer"
@@ -352,3 +355,82 @@ func TestProcessBucketLocationResponse(t *testing.T) {
 		}
 	}
 }
+
+// Tests locations expire from caches created with a ttl.
+func TestExpiringBucketLocationCache(t *testing.T) {
+	cache := NewBucketLocationCache(50 * time.Millisecond)
+	cache.Set("bucket", "eu-west-1")
+	if location, ok := cache.Get("bucket"); !ok || location != "eu-west-1" {
+		t.Fatalf("Expected cached location eu-west-1, got %q", location)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, ok := cache.Get("bucket"); ok {
+		t.Fatal("Expected location to expire")
+	}
+
+	cache = NewBucketLocationCache(0)
+	cache.Set("bucket", "eu-west-1")
+	cache.Delete("bucket")
+	if _, ok := cache.Get("bucket"); ok {
+		t.Fatal("Expected location to be deleted")
+	}
+}
+
+// Tests clients sharing a location cache resolve locations once, and
+// invalidate locations when redirected.
+func TestSharedBucketLocationCache(t *testing.T) {
+	var mutex sync.Mutex
+	var lookups int
+	var redirect bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if _, ok := r.URL.Query()["location"]; ok {
+			lookups++
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">eu-west-1</LocationConstraint>`))
+			return
+		}
+		if redirect {
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	cache := NewBucketLocationCache(time.Hour)
+	var clients []*Client
+	for i := 0; i < 3; i++ {
+		clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		clnt.SetBucketLocationCache(cache)
+		clients = append(clients, clnt)
+	}
+	for _, clnt := range clients {
+		if _, err := clnt.StatObject("bucket", "object"); err != nil {
+			t.Fatal("Error:", err)
+		}
+	}
+	if lookups != 1 {
+		t.Fatalf("Expected a single location lookup, got %d", lookups)
+	}
+
+	// A redirect without a region invalidates the cached location.
+	redirect = true
+	if _, err := clients[0].StatObject("bucket", "object"); err == nil {
+		t.Fatal("Expected redirected request to fail")
+	}
+	if _, ok := cache.Get("bucket"); ok {
+		t.Fatal("Expected location to be invalidated")
+	}
+	redirect = false
+	if _, err := clients[1].StatObject("bucket", "object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if lookups != 2 {
+		t.Fatalf("Expected location to be resolved again, got %d lookups", lookups)
+	}
+}
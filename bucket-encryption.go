@@ -0,0 +1,87 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import "encoding/xml"
+
+// Server side encryption algorithms applied by default to new
+// objects in a bucket.
+const (
+	// SSEAlgorithmAES256 - encryption with keys managed by the server (SSE-S3).
+	SSEAlgorithmAES256 = "AES256"
+	// SSEAlgorithmKMS - encryption with keys managed by a KMS (SSE-KMS).
+	SSEAlgorithmKMS = "aws:kms"
+)
+
+// ApplyServerSideEncryptionByDefault - default encryption applied to
+// objects created without encryption headers.
+type ApplyServerSideEncryptionByDefault struct {
+	SSEAlgorithm   string `xml:"SSEAlgorithm"`
+	KMSMasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+}
+
+// ServerSideEncryptionRule - rule of a bucket encryption configuration.
+type ServerSideEncryptionRule struct {
+	Apply ApplyServerSideEncryptionByDefault `xml:"ApplyServerSideEncryptionByDefault"`
+}
+
+// ServerSideEncryptionConfiguration - default encryption configuration
+// of a bucket.
+type ServerSideEncryptionConfiguration struct {
+	XMLName xml.Name                   `xml:"ServerSideEncryptionConfiguration"`
+	Rules   []ServerSideEncryptionRule `xml:"Rule"`
+}
+
+// NewSSES3EncryptionConfig - returns a configuration encrypting new
+// objects with keys managed by the server.
+func NewSSES3EncryptionConfig() ServerSideEncryptionConfiguration {
+	return ServerSideEncryptionConfiguration{
+		Rules: []ServerSideEncryptionRule{{
+			Apply: ApplyServerSideEncryptionByDefault{SSEAlgorithm: SSEAlgorithmAES256},
+		}},
+	}
+}
+
+// NewSSEKMSEncryptionConfig - returns a configuration encrypting new
+// objects with the KMS master key keyID. An empty keyID selects the
+// default key of the KMS.
+func NewSSEKMSEncryptionConfig(keyID string) ServerSideEncryptionConfiguration {
+	return ServerSideEncryptionConfiguration{
+		Rules: []ServerSideEncryptionRule{{
+			Apply: ApplyServerSideEncryptionByDefault{SSEAlgorithm: SSEAlgorithmKMS, KMSMasterKeyID: keyID},
+		}},
+	}
+}
+
+// validate - verifies if the configuration can be applied.
+func (config ServerSideEncryptionConfiguration) validate() error {
+	if len(config.Rules) != 1 {
+		return ErrInvalidArgument("Encryption configuration should have exactly one rule.")
+	}
+	apply := config.Rules[0].Apply
+	switch apply.SSEAlgorithm {
+	case SSEAlgorithmAES256:
+		if apply.KMSMasterKeyID != "" {
+			return ErrInvalidArgument("KMS master key ID can only be set with aws:kms algorithm.")
+		}
+	case SSEAlgorithmKMS:
+	default:
+		return ErrInvalidArgument("Encryption algorithm should be either AES256 or aws:kms.")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests validation and encoding of bucket encryption configurations.
+func TestServerSideEncryptionConfiguration(t *testing.T) {
+	testCases := []struct {
+		config  ServerSideEncryptionConfiguration
+		xml     string
+		isValid bool
+	}{
+		{NewSSES3EncryptionConfig(), `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, true},
+		{NewSSEKMSEncryptionConfig("my-key"), `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`, true},
+		{NewSSEKMSEncryptionConfig(""), "", true},
+		{ServerSideEncryptionConfiguration{}, "", false},
+		{ServerSideEncryptionConfiguration{Rules: []ServerSideEncryptionRule{{Apply: ApplyServerSideEncryptionByDefault{SSEAlgorithm: "DES"}}}}, "", false},
+		{ServerSideEncryptionConfiguration{Rules: []ServerSideEncryptionRule{{Apply: ApplyServerSideEncryptionByDefault{SSEAlgorithm: SSEAlgorithmAES256, KMSMasterKeyID: "key"}}}}, "", false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.config.validate()
+		if testCase.isValid && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.isValid && err == nil {
+			t.Errorf("Test %d: expected to fail", i+1)
+		}
+		if testCase.xml == "" {
+			continue
+		}
+		data, err := xml.Marshal(testCase.config)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if string(data) != testCase.xml {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.xml, data)
+		}
+	}
+}
+
+// Tests setting, getting and deleting the bucket encryption configuration.
+func TestBucketEncryption(t *testing.T) {
+	var stored []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if _, ok := query["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		if _, ok := query["encryption"]; !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case "PUT":
+			stored, _ = ioutil.ReadAll(r.Body)
+		case "GET":
+			if stored == nil {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`<Error><Code>ServerSideEncryptionConfigurationNotFoundError</Code><Message>The server side encryption configuration was not found</Message></Error>`))
+				return
+			}
+			w.Write(stored)
+		case "DELETE":
+			stored = nil
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewV4(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.SetBucketEncryption("bucket", NewSSEKMSEncryptionConfig("my-key")); err != nil {
+		t.Fatal("Error:", err)
+	}
+	config, err := clnt.GetBucketEncryption("bucket")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(config.Rules) != 1 || config.Rules[0].Apply.SSEAlgorithm != SSEAlgorithmKMS || config.Rules[0].Apply.KMSMasterKeyID != "my-key" {
+		t.Fatalf("Unexpected configuration %#v", config)
+	}
+	if err = clnt.DeleteBucketEncryption("bucket"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = clnt.GetBucketEncryption("bucket"); ToErrorResponse(err).Code != "ServerSideEncryptionConfigurationNotFoundError" {
+		t.Fatalf("Expected configuration not found, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"encoding/xml"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// BucketLogging - access logging configuration of a bucket. Access
+// logs of the bucket are delivered to TargetBucket as objects whose
+// names start with TargetPrefix. The zero value disables logging.
+type BucketLogging struct {
+	TargetBucket string
+	TargetPrefix string
+}
+
+// Enabled - tells whether access logging is enabled.
+func (logging BucketLogging) Enabled() bool {
+	return logging.TargetBucket != ""
+}
+
+// validate - verifies if the configuration can be applied.
+func (logging BucketLogging) validate() error {
+	if !logging.Enabled() {
+		if logging.TargetPrefix != "" {
+			return ErrInvalidArgument("Target prefix cannot be set without a target bucket.")
+		}
+		return nil
+	}
+	if err := s3utils.CheckValidBucketName(logging.TargetBucket); err != nil {
+		return err
+	}
+	return s3utils.CheckValidObjectNamePrefix(logging.TargetPrefix)
+}
+
+// loggingEnabled - container for the logging target of a bucket.
+type loggingEnabled struct {
+	TargetBucket string `xml:"TargetBucket"`
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+// bucketLoggingStatus - container for bucket logging requests and
+// responses, without LoggingEnabled logging is disabled.
+type bucketLoggingStatus struct {
+	XMLName        xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ BucketLoggingStatus"`
+	LoggingEnabled *loggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
@@ -0,0 +1,216 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker - fails requests to an endpoint fast during a cooldown
+// once too many requests to it failed, so a degraded server is not
+// flooded further. After the cooldown a single request is let through,
+// its success closes the circuit again. A circuit breaker can be
+// shared by clients with SetCircuitBreaker, endpoints are told apart
+// by host. Circuit breakers are created with NewCircuitBreaker.
+type CircuitBreaker struct {
+	// Rate, between 0 and 1, of requests failing within window
+	// which opens the circuit, once at least minRequests requests
+	// were sent.
+	errorRate   float64
+	minRequests int
+	window      time.Duration
+
+	// Cooldown during which requests fail fast once the circuit
+	// is open.
+	cooldown time.Duration
+
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit - state of the circuit of an endpoint.
+type circuit struct {
+	windowStart time.Time
+	requests    int
+	failures    int
+
+	// Set while the circuit is open.
+	openUntil time.Time
+	// Set while the request probing an endpoint after the cooldown
+	// is in flight.
+	probing bool
+}
+
+// NewCircuitBreaker - returns a circuit breaker opening the circuit of
+// an endpoint for cooldown when at least errorRate of minRequests or
+// more requests within window failed.
+func NewCircuitBreaker(errorRate float64, minRequests int, window, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		errorRate:   errorRate,
+		minRequests: minRequests,
+		window:      window,
+		cooldown:    cooldown,
+	}
+}
+
+// ErrCircuitOpen - requests to host are failed fast.
+func ErrCircuitOpen(host string, until time.Time) error {
+	return ErrorResponse{
+		Code:      "CircuitOpen",
+		Message:   fmt.Sprintf("Too many requests to %s failed, requests are failed fast until %s.", host, until.Format(time.RFC3339)),
+		RequestID: "minio",
+	}
+}
+
+// allow - returns an error if a request to host should fail fast.
+func (b *CircuitBreaker) allow(host string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	c, ok := b.circuits[host]
+	if !ok || c.openUntil.IsZero() {
+		return nil
+	}
+	if time.Now().Before(c.openUntil) || c.probing {
+		return ErrCircuitOpen(host, c.openUntil)
+	}
+	// Cooldown is over, probe the endpoint with this request.
+	c.probing = true
+	return nil
+}
+
+// record - records the outcome of a request to host allowed earlier.
+func (b *CircuitBreaker) record(host string, failed bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	now := time.Now()
+	if b.circuits == nil {
+		b.circuits = make(map[string]*circuit)
+	}
+	c, ok := b.circuits[host]
+	if !ok {
+		c = &circuit{windowStart: now}
+		b.circuits[host] = c
+	}
+
+	if c.probing {
+		c.probing = false
+		if failed {
+			c.openUntil = now.Add(b.cooldown)
+			return
+		}
+		*c = circuit{windowStart: now}
+		return
+	}
+	if !c.openUntil.IsZero() {
+		// Request sent before the circuit opened.
+		return
+	}
+
+	if now.Sub(c.windowStart) > b.window {
+		*c = circuit{windowStart: now}
+	}
+	c.requests++
+	if failed {
+		c.failures++
+	}
+	if c.requests >= b.minRequests && float64(c.failures) >= b.errorRate*float64(c.requests) && c.failures > 0 {
+		*c = circuit{windowStart: now, openUntil: now.Add(b.cooldown)}
+	}
+}
+
+// isCircuitFailure - tells whether a request failed because of the
+// server, network errors and server errors count as such.
+func isCircuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// SetCircuitBreaker - fails requests fast while the circuit of their
+// endpoint is open in breaker, nil disables the circuit breaker.
+func (c *Client) SetCircuitBreaker(breaker *CircuitBreaker) {
+	c.circuitBreaker = breaker
+}
+
+// RetryBudget - limits retries of all the requests sharing it, so
+// clients do not amplify the load of a degraded server. Retries draw
+// from a bucket of tokens refilled at a fixed rate.
+type RetryBudget struct {
+	mutex      *sync.Mutex
+	capacity   float64
+	refillRate float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// NewRetryBudget - returns a budget of capacity retries, refilled by
+// refillPerSecond retries every second.
+func NewRetryBudget(capacity int, refillPerSecond float64) *RetryBudget {
+	return &RetryBudget{
+		mutex:      &sync.Mutex{},
+		capacity:   float64(capacity),
+		refillRate: refillPerSecond,
+		tokens:     float64(capacity),
+		lastRefill: time.Now(),
+	}
+}
+
+// withdraw - takes a retry out of the budget, returns false if none
+// is left.
+func (b *RetryBudget) withdraw() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	now := time.Now()
+	b.tokens += now.Sub(b.lastRefill).Seconds() * b.refillRate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.lastRefill = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// budgetedRetryPolicy - retries as policy does while budget lasts.
+type budgetedRetryPolicy struct {
+	policy RetryPolicy
+	budget *RetryBudget
+}
+
+// WithRetryBudget - returns a retry policy retrying as policy does as
+// long as budget has retries left, budget can be shared by the
+// policies of many clients.
+func WithRetryBudget(policy RetryPolicy, budget *RetryBudget) RetryPolicy {
+	return budgetedRetryPolicy{policy, budget}
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p budgetedRetryPolicy) ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) bool {
+	return p.policy.ShouldRetry(attempt, req, resp, err) && p.budget.withdraw()
+}
+
+// Backoff implements RetryPolicy.
+func (p budgetedRetryPolicy) Backoff(attempt int) time.Duration {
+	return p.policy.Backoff(attempt)
+}
@@ -0,0 +1,122 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests the circuit of a failing endpoint opens, fails fast during the
+// cooldown and closes once a probe succeeds.
+func TestCircuitBreaker(t *testing.T) {
+	var requests int
+	healthy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Length", "0")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer server.Close()
+
+	client, err := NewWithRegion(server.Listener.Addr().String(), "key", "secret", false, "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetRetryPolicy(&countingRetryPolicy{maxRetry: 1})
+	cooldown := 100 * time.Millisecond
+	client.SetCircuitBreaker(NewCircuitBreaker(0.5, 2, time.Minute, cooldown))
+
+	for i := 0; i < 2; i++ {
+		if _, err = client.StatObject("bucket", "object"); err == nil {
+			t.Fatal("Error: expected request to fail")
+		}
+	}
+	if _, err = client.StatObject("bucket", "object"); ToErrorResponse(err).Code != "CircuitOpen" {
+		t.Fatalf("Error: expected CircuitOpen, got %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("Error: expected 2 requests, got %d", requests)
+	}
+
+	time.Sleep(cooldown)
+	healthy = true
+	if _, err = client.StatObject("bucket", "object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = client.StatObject("bucket", "object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if requests != 4 {
+		t.Fatalf("Error: expected 4 requests, got %d", requests)
+	}
+}
+
+// Tests a failed probe reopens the circuit.
+func TestCircuitBreakerProbeFailure(t *testing.T) {
+	breaker := NewCircuitBreaker(1, 1, time.Minute, 50*time.Millisecond)
+	breaker.record("host", true)
+	if breaker.allow("host") == nil {
+		t.Fatal("Error: expected circuit to be open")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := breaker.allow("host"); err != nil {
+		t.Fatal("Error: expected probe to be allowed,", err)
+	}
+	if breaker.allow("host") == nil {
+		t.Fatal("Error: expected a single probe")
+	}
+	breaker.record("host", true)
+	if breaker.allow("host") == nil {
+		t.Fatal("Error: expected circuit to be reopened")
+	}
+	if breaker.allow("other") != nil {
+		t.Fatal("Error: expected other endpoints unaffected")
+	}
+}
+
+// Tests retries stop once the shared retry budget is spent.
+func TestRetryBudget(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	budget := NewRetryBudget(3, 0)
+	for i := 0; i < 2; i++ {
+		client, err := NewWithRegion(server.Listener.Addr().String(), "key", "secret", false, "us-east-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.SetRetryPolicy(WithRetryBudget(&countingRetryPolicy{maxRetry: 10}, budget))
+		client.StatObject("bucket", "object")
+	}
+	// One request per client, plus the three budgeted retries.
+	if requests != 5 {
+		t.Fatalf("Error: expected 5 requests, got %d", requests)
+	}
+}
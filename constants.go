@@ -22,6 +22,10 @@ package minio
 // putObject behaves internally as multipart.
 const minPartSize = 1024 * 1024 * 64
 
+// absMinPartSize - absolute minimum part size 5MiB allowed by S3 for
+// all parts of a multipart upload except the last one.
+const absMinPartSize = 1024 * 1024 * 5
+
 // maxPartsCount - maximum number of parts for a single multipart session.
 const maxPartsCount = 10000
 
@@ -52,7 +52,9 @@ func (c Core) ListObjectsV2(bucketName, objectPrefix, continuationToken string,
 	return c.listObjectsV2Query(bucketName, objectPrefix, continuationToken, fetchOwner, delimiter, maxkeys)
 }
 
-// PutObject - Upload object. Uploads using single PUT call.
+// PutObject - Upload object. Uploads using single PUT call. The
+// returned object info carries the ETag, the lifecycle expiration and
+// the replication status of the new object.
 func (c Core) PutObject(bucket, object string, size int64, data io.Reader, md5Sum, sha256Sum []byte, metadata map[string][]string) (ObjectInfo, error) {
 	return c.putObjectDo(bucket, object, data, md5Sum, sha256Sum, size, metadata)
 }
@@ -0,0 +1,179 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Canned ACLs, predefined sets of grants.
+const (
+	ACLPrivate                = "private"
+	ACLPublicRead             = "public-read"
+	ACLPublicReadWrite        = "public-read-write"
+	ACLAuthenticatedRead      = "authenticated-read"
+	ACLBucketOwnerRead        = "bucket-owner-read"
+	ACLBucketOwnerFullControl = "bucket-owner-full-control"
+)
+
+// Permissions granted by ACL grants.
+const (
+	PermissionFullControl = "FULL_CONTROL"
+	PermissionRead        = "READ"
+	PermissionWrite       = "WRITE"
+	PermissionReadACP     = "READ_ACP"
+	PermissionWriteACP    = "WRITE_ACP"
+)
+
+// Predefined groups grants can be given to.
+const (
+	GroupAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	GroupAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// Grantee - receiver of a grant, identified by exactly one of its
+// canonical user ID, group URI or email address.
+type Grantee struct {
+	// Type of grantee, CanonicalUser, Group or AmazonCustomerByEmail.
+	// Only set on returned grants.
+	Type         string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+	ID           string `xml:"ID"`
+	DisplayName  string `xml:"DisplayName"`
+	URI          string `xml:"URI"`
+	EmailAddress string `xml:"EmailAddress"`
+}
+
+// Grant - permission given to a grantee.
+type Grant struct {
+	Grantee    Grantee `xml:"Grantee"`
+	Permission string  `xml:"Permission"`
+}
+
+// ObjectACL - access control list of an object.
+type ObjectACL struct {
+	// Canned ACL to apply, or matching the returned grants if any.
+	CannedACL string
+
+	// Owner of the object, only set on returned ACLs.
+	Owner struct {
+		DisplayName string
+		ID          string
+	}
+
+	// Grants of the ACL, to apply instead of a canned ACL.
+	Grants []Grant
+}
+
+// accessControlPolicy - container for get object ACL responses.
+type accessControlPolicy struct {
+	Owner             owner
+	AccessControlList struct {
+		Grants []Grant `xml:"Grant"`
+	}
+}
+
+// aclGrantHeaders - maps permissions to the headers granting them.
+var aclGrantHeaders = map[string]string{
+	PermissionFullControl: "X-Amz-Grant-Full-Control",
+	PermissionRead:        "X-Amz-Grant-Read",
+	PermissionWrite:       "X-Amz-Grant-Write",
+	PermissionReadACP:     "X-Amz-Grant-Read-Acp",
+	PermissionWriteACP:    "X-Amz-Grant-Write-Acp",
+}
+
+// header - returns the headers applying the ACL.
+func (acl ObjectACL) header() (http.Header, error) {
+	header := make(http.Header)
+	if acl.CannedACL != "" {
+		if len(acl.Grants) > 0 {
+			return nil, ErrInvalidArgument("Canned ACL and grants cannot be set together.")
+		}
+		switch acl.CannedACL {
+		case ACLPrivate, ACLPublicRead, ACLPublicReadWrite, ACLAuthenticatedRead,
+			ACLBucketOwnerRead, ACLBucketOwnerFullControl:
+		default:
+			return nil, ErrInvalidArgument(fmt.Sprintf("Unknown canned ACL ‘%s’.", acl.CannedACL))
+		}
+		header.Set("X-Amz-Acl", acl.CannedACL)
+		return header, nil
+	}
+	if len(acl.Grants) == 0 {
+		return nil, ErrInvalidArgument("Either a canned ACL or grants should be set.")
+	}
+
+	grantees := make(map[string][]string)
+	for _, grant := range acl.Grants {
+		key, ok := aclGrantHeaders[grant.Permission]
+		if !ok {
+			return nil, ErrInvalidArgument(fmt.Sprintf("Unknown permission ‘%s’.", grant.Permission))
+		}
+		var grantee []string
+		if grant.Grantee.ID != "" {
+			grantee = append(grantee, fmt.Sprintf("id=%q", grant.Grantee.ID))
+		}
+		if grant.Grantee.URI != "" {
+			grantee = append(grantee, fmt.Sprintf("uri=%q", grant.Grantee.URI))
+		}
+		if grant.Grantee.EmailAddress != "" {
+			grantee = append(grantee, fmt.Sprintf("emailAddress=%q", grant.Grantee.EmailAddress))
+		}
+		if len(grantee) != 1 {
+			return nil, ErrInvalidArgument("Grantee should be identified by exactly one of ID, URI or email address.")
+		}
+		grantees[key] = append(grantees[key], grantee[0])
+	}
+	for key, values := range grantees {
+		header.Set(key, strings.Join(values, ", "))
+	}
+	return header, nil
+}
+
+// cannedACL - returns the canned ACL granting the same permissions
+// as grants to an object owned by ownerID, empty if there is none.
+func cannedACL(ownerID string, grants []Grant) string {
+	var others []string
+	ownerFullControl := false
+	for _, grant := range grants {
+		if grant.Grantee.ID == ownerID && grant.Permission == PermissionFullControl {
+			ownerFullControl = true
+			continue
+		}
+		if grant.Grantee.URI == "" {
+			return ""
+		}
+		others = append(others, grant.Grantee.URI+" "+grant.Permission)
+	}
+	if !ownerFullControl {
+		return ""
+	}
+	sort.Strings(others)
+	switch strings.Join(others, ",") {
+	case "":
+		return ACLPrivate
+	case GroupAllUsers + " " + PermissionRead:
+		return ACLPublicRead
+	case GroupAllUsers + " " + PermissionRead + "," + GroupAllUsers + " " + PermissionWrite:
+		return ACLPublicReadWrite
+	case GroupAuthenticatedUsers + " " + PermissionRead:
+		return ACLAuthenticatedRead
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// RetentionMode - object retention mode applied with object lock.
+type RetentionMode string
+
+const (
+	// Governance - objects can be overwritten or deleted only by users
+	// with special permissions before the retention period expires.
+	Governance RetentionMode = "GOVERNANCE"
+	// Compliance - objects cannot be overwritten or deleted by any
+	// user before the retention period expires.
+	Compliance RetentionMode = "COMPLIANCE"
+)
+
+// IsValid - verifies if the retention mode is supported.
+func (r RetentionMode) IsValid() bool {
+	return r == Governance || r == Compliance
+}
+
+// LegalHoldStatus - status of a legal hold placed on an object.
+type LegalHoldStatus string
+
+const (
+	// LegalHoldOn - legal hold is placed on the object.
+	LegalHoldOn LegalHoldStatus = "ON"
+	// LegalHoldOff - legal hold is released from the object.
+	LegalHoldOff LegalHoldStatus = "OFF"
+)
+
+// IsValid - verifies if the legal hold status is supported.
+func (l LegalHoldStatus) IsValid() bool {
+	return l == LegalHoldOn || l == LegalHoldOff
+}
+
+// Object lock headers set on bucket and object creation and removal.
+const (
+	amzBucketObjectLockEnabled   = "X-Amz-Bucket-Object-Lock-Enabled"
+	amzObjectLockMode            = "X-Amz-Object-Lock-Mode"
+	amzObjectLockRetainUntilDate = "X-Amz-Object-Lock-Retain-Until-Date"
+	amzObjectLockLegalHold       = "X-Amz-Object-Lock-Legal-Hold"
+	amzBypassGovernance          = "X-Amz-Bypass-Governance-Retention"
+)
+
+// ObjectLock - object lock settings applied atomically while creating
+// an object in a bucket with object lock enabled.
+//
+// Mode and RetainUntilDate must be set together, LegalHold can be set
+// independently of the retention settings.
+type ObjectLock struct {
+	Mode            RetentionMode
+	RetainUntilDate time.Time
+	LegalHold       LegalHoldStatus
+}
+
+// validate - verifies if object lock settings are consistent.
+func (l ObjectLock) validate() error {
+	if l.Mode != "" || !l.RetainUntilDate.IsZero() {
+		if !l.Mode.IsValid() {
+			return ErrInvalidArgument("Retention mode should be either GOVERNANCE or COMPLIANCE.")
+		}
+		if l.RetainUntilDate.IsZero() {
+			return ErrInvalidArgument("Retain until date cannot be empty with a retention mode.")
+		}
+		if !l.RetainUntilDate.After(time.Now()) {
+			return ErrInvalidArgument("Retain until date should be in the future.")
+		}
+	}
+	if l.LegalHold != "" && !l.LegalHold.IsValid() {
+		return ErrInvalidArgument("Legal hold status should be either ON or OFF.")
+	}
+	return nil
+}
+
+// header - returns object lock settings as request headers.
+func (l ObjectLock) header() http.Header {
+	header := make(http.Header)
+	if l.Mode != "" {
+		header.Set(amzObjectLockMode, string(l.Mode))
+		header.Set(amzObjectLockRetainUntilDate, l.RetainUntilDate.UTC().Format(time.RFC3339))
+	}
+	if l.LegalHold != "" {
+		header.Set(amzObjectLockLegalHold, string(l.LegalHold))
+	}
+	return header
+}
+
+// isObjectLockRequest - verifies if metadata carries object lock headers.
+func isObjectLockRequest(metaData map[string][]string) bool {
+	for key := range metaData {
+		switch http.CanonicalHeaderKey(key) {
+		case amzObjectLockMode, amzObjectLockRetainUntilDate, amzObjectLockLegalHold:
+			return true
+		}
+	}
+	return false
+}
+
+// PutObjectWithObjectLock - creates an object with retention and legal
+// hold settings applied in the same request which creates the object,
+// so there is no window during which the object is not protected.
+func (c Client) PutObjectWithObjectLock(bucketName, objectName string, reader io.Reader, metaData map[string][]string, lock ObjectLock, progress io.Reader) (n int64, err error) {
+	if err = lock.validate(); err != nil {
+		return 0, err
+	}
+
+	// Do not modify the metadata map provided by the caller.
+	lockMetaData := make(map[string][]string)
+	for k, v := range metaData {
+		lockMetaData[k] = v
+	}
+	for k, v := range lock.header() {
+		lockMetaData[k] = v
+	}
+	return c.PutObjectWithMetadata(bucketName, objectName, reader, lockMetaData, progress)
+}
+
+// MakeBucketWithObjectLock - creates a bucket with object lock enabled,
+// which is only possible while creating the bucket. Versioning is
+// enabled along with object lock and cannot be suspended.
+func (c Client) MakeBucketWithObjectLock(bucketName string, location string) error {
+	customHeader := make(http.Header)
+	customHeader.Set(amzBucketObjectLockEnabled, "true")
+	return c.makeBucket(bucketName, location, customHeader)
+}
@@ -0,0 +1,152 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests validation of object lock settings.
+func TestObjectLockValidate(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	testCases := []struct {
+		lock    ObjectLock
+		success bool
+	}{
+		{ObjectLock{}, true},
+		{ObjectLock{LegalHold: LegalHoldOn}, true},
+		{ObjectLock{Mode: Governance, RetainUntilDate: future}, true},
+		{ObjectLock{Mode: Compliance, RetainUntilDate: future, LegalHold: LegalHoldOff}, true},
+		{ObjectLock{Mode: Governance}, false},
+		{ObjectLock{RetainUntilDate: future}, false},
+		{ObjectLock{Mode: "INVALID", RetainUntilDate: future}, false},
+		{ObjectLock{Mode: Governance, RetainUntilDate: time.Now().Add(-time.Hour)}, false},
+		{ObjectLock{LegalHold: "MAYBE"}, false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.lock.validate()
+		if err != nil && testCase.success {
+			t.Errorf("Test %d: Expected to succeed but failed with %s", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Errorf("Test %d: Expected to fail but succeeded", i+1)
+		}
+	}
+}
+
+// Tests object lock settings are converted to headers.
+func TestObjectLockHeader(t *testing.T) {
+	until := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	header := ObjectLock{Mode: Compliance, RetainUntilDate: until, LegalHold: LegalHoldOn}.header()
+	if v := header.Get(amzObjectLockMode); v != "COMPLIANCE" {
+		t.Fatalf("Expected mode COMPLIANCE, got %s", v)
+	}
+	if v := header.Get(amzObjectLockRetainUntilDate); v != "2030-01-02T03:04:05Z" {
+		t.Fatalf("Expected retain until date 2030-01-02T03:04:05Z, got %s", v)
+	}
+	if v := header.Get(amzObjectLockLegalHold); v != "ON" {
+		t.Fatalf("Expected legal hold ON, got %s", v)
+	}
+	if !isObjectLockRequest(header) {
+		t.Fatal("Expected headers to be recognized as an object lock request")
+	}
+	if isObjectLockRequest(map[string][]string{"Content-Type": {"text/plain"}}) {
+		t.Fatal("Expected metadata not to be recognized as an object lock request")
+	}
+	if !isObjectLockRequest(map[string][]string{"x-amz-object-lock-legal-hold": {"ON"}}) {
+		t.Fatal("Expected lower case metadata to be recognized as an object lock request")
+	}
+}
+
+// Tests parts of multipart object lock uploads carry Content-MD5, even
+// on insecure connections where it is not computed otherwise.
+func TestPutObjectWithObjectLockMultipart(t *testing.T) {
+	var partMD5s []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		_, location := query["location"]
+		_, uploads := query["uploads"]
+		switch {
+		case location:
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+		case r.Method == "POST" && uploads:
+			fmt.Fprint(w, `<InitiateMultipartUploadResult><UploadId>upload</UploadId></InitiateMultipartUploadResult>`)
+		case r.Method == "PUT" && query.Get("partNumber") != "":
+			ioutil.ReadAll(r.Body)
+			partMD5s = append(partMD5s, r.Header.Get("Content-Md5"))
+			w.Header().Set("ETag", `"etag"`)
+		case r.Method == "POST" && query.Get("uploadId") != "":
+			fmt.Fprint(w, `<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><ETag>"etag"</ETag></CompleteMultipartUploadResult>`)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+
+	clnt, err := NewWithRegion(server.Listener.Addr().String(), "access", "secret", false, "us-east-1")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	// The reader of unknown size is uploaded using multipart.
+	reader := io.MultiReader(strings.NewReader(strings.Repeat("a", minPartSize+1)))
+	lock := ObjectLock{Mode: Governance, RetainUntilDate: time.Now().Add(time.Hour)}
+	if _, err = clnt.PutObjectWithObjectLock("bucket", "object", reader, nil, lock, nil); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(partMD5s) == 0 {
+		t.Fatal("Expected parts to be uploaded")
+	}
+	for i, sum := range partMD5s {
+		if sum == "" {
+			t.Errorf("Expected part %d to carry Content-MD5", i+1)
+		}
+	}
+}
+
+// Tests buckets are created with object lock enabled on request.
+func TestMakeBucketWithObjectLock(t *testing.T) {
+	var lockEnabled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Unexpected %s request", r.Method)
+		}
+		lockEnabled = append(lockEnabled, r.Header.Get(amzBucketObjectLockEnabled))
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.MakeBucketWithObjectLock("locked-bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = clnt.MakeBucket("plain-bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(lockEnabled) != 2 || lockEnabled[0] != "true" || lockEnabled[1] != "" {
+		t.Fatalf("Expected object lock to be enabled only on the first bucket, got %q", lockEnabled)
+	}
+}
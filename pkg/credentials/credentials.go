@@ -110,6 +110,11 @@ type Credentials struct {
 	creds        Value
 	forceRefresh bool
 	provider     Provider
+
+	// Lifecycle events handler, see SetEventHandler.
+	handler       func(Event)
+	expiryWarning time.Duration
+	warned        bool
 }
 
 // New returns a pointer to a new Credentials with the provider set.
@@ -131,18 +136,37 @@ func New(provider Provider) *Credentials {
 // expired, and the next call to Get() will cause them to be refreshed.
 func (c *Credentials) Get() (Value, error) {
 	c.Lock()
-	defer c.Unlock()
+	creds, events, err := c.get()
+	handler := c.handler
+	c.Unlock()
 
-	if c.isExpired() {
-		creds, err := c.provider.Retrieve()
-		if err != nil {
-			return Value{}, err
+	if handler != nil {
+		for _, event := range events {
+			handler(event)
 		}
-		c.creds = creds
-		c.forceRefresh = false
+	}
+	return creds, err
+}
+
+// get helper method refreshing credentials if needed, returns the
+// lifecycle events observed on the way.
+func (c *Credentials) get() (Value, []Event, error) {
+	if !c.isExpired() {
+		return c.creds, c.expiringEvent(), nil
 	}
 
-	return c.creds, nil
+	creds, err := c.provider.Retrieve()
+	if err != nil {
+		return Value{}, []Event{{Type: EventRefreshFailed, Err: err}}, err
+	}
+	c.creds = creds
+	c.forceRefresh = false
+	c.warned = false
+	return c.creds, []Event{{
+		Type:        EventRefreshed,
+		AccessKeyID: creds.AccessKeyID,
+		Expiration:  c.expiration(),
+	}}, nil
 }
 
 // Expire expires the credentials and forces them to be retrieved on the
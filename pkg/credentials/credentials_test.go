@@ -20,6 +20,7 @@ package credentials
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 type credProvider struct {
@@ -71,3 +72,63 @@ func TestCredentialsGetWithError(t *testing.T) {
 		}
 	}
 }
+
+type expiringProvider struct {
+	Expiry
+	expiration time.Time
+}
+
+func (p *expiringProvider) Retrieve() (Value, error) {
+	p.SetExpiration(p.expiration, 0)
+	return Value{AccessKeyID: "UXHW"}, nil
+}
+
+func TestCredentialsEvents(t *testing.T) {
+	provider := &credProvider{expired: true, err: errors.New("Custom error")}
+	c := New(provider)
+	var events []Event
+	c.SetEventHandler(func(e Event) {
+		// Handler may call back into credentials.
+		c.IsExpired()
+		events = append(events, e)
+	}, time.Minute)
+
+	c.Get()
+	provider.err = nil
+	provider.creds = Value{AccessKeyID: "UXHW"}
+	c.Get()
+	c.Get()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != EventRefreshFailed || events[0].Err == nil {
+		t.Errorf("Expected refresh failure, got %v", events[0])
+	}
+	if events[1].Type != EventRefreshed || events[1].AccessKeyID != "UXHW" {
+		t.Errorf("Expected refresh, got %v", events[1])
+	}
+}
+
+func TestCredentialsExpiringEvent(t *testing.T) {
+	expiration := time.Now().Add(30 * time.Second)
+	c := New(&expiringProvider{expiration: expiration})
+	var events []Event
+	c.SetEventHandler(func(e Event) {
+		events = append(events, e)
+	}, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Get(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != EventRefreshed || !events[0].Expiration.Equal(expiration) {
+		t.Errorf("Expected refresh expiring at %s, got %v", expiration, events[0])
+	}
+	if events[1].Type != EventExpiring || events[1].AccessKeyID != "UXHW" {
+		t.Errorf("Expected expiry warning, got %v", events[1])
+	}
+}
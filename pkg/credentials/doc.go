@@ -27,6 +27,14 @@
 //     credsValue, err := creds.Get()
 //     // New credentials will be retrieved instead of from cache.
 //
+// Example of logging credentials rotation, refresh failures, and
+// credentials expiring within five minutes.
+//
+//     creds := NewFromIAM("")
+//     creds.SetEventHandler(func(e Event) {
+//         log.Println(e.Type, e.AccessKeyID, e.Expiration, e.Err)
+//     }, 5*time.Minute)
+//
 //
 // Custom Provider
 //
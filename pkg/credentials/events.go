@@ -0,0 +1,115 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import "time"
+
+// EventType - kind of credentials lifecycle event.
+type EventType string
+
+// Credentials lifecycle events.
+const (
+	// EventRefreshed - credentials were retrieved from the provider.
+	EventRefreshed EventType = "Refreshed"
+	// EventExpiring - cached credentials expire within the warning
+	// period configured with SetEventHandler.
+	EventExpiring EventType = "Expiring"
+	// EventRefreshFailed - the provider failed to retrieve credentials.
+	EventRefreshFailed EventType = "RefreshFailed"
+)
+
+// An Event describes a change in the lifecycle of credentials.
+type Event struct {
+	Type EventType
+
+	// Access key ID of the credentials refreshed or expiring.
+	AccessKeyID string
+
+	// Expiration of the credentials, zero when the provider does
+	// not tell.
+	Expiration time.Time
+
+	// Error the refresh failed with.
+	Err error
+}
+
+// An expirer is a Provider telling when its credentials expire,
+// providers embedding Expiry are expirers.
+type expirer interface {
+	ExpiresAt() time.Time
+}
+
+// ExpiresAt returns when the credentials expire, reduced by the window
+// given to SetExpiration.
+func (e *Expiry) ExpiresAt() time.Time {
+	return e.expiration
+}
+
+// ExpiresAt returns when the credentials of the currently cached
+// provider expire, zero if it does not tell.
+func (c *Chain) ExpiresAt() time.Time {
+	if e, ok := c.curr.(expirer); ok {
+		return e.ExpiresAt()
+	}
+	return time.Time{}
+}
+
+// SetEventHandler sets the handler called when credentials are
+// refreshed, fail to refresh, or expire within expiryWarning. Events
+// are observed by Get, which runs before each request is signed, and
+// the handler is called once Get released the credentials lock, so it
+// may call back into Credentials. Passing nil removes the handler.
+//
+//     creds.SetEventHandler(func(e credentials.Event) {
+//         log.Println("credentials", e.Type, e.AccessKeyID, e.Expiration, e.Err)
+//     }, 5*time.Minute)
+//
+func (c *Credentials) SetEventHandler(handler func(Event), expiryWarning time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.handler = handler
+	c.expiryWarning = expiryWarning
+}
+
+// expiration returns when the cached credentials expire, zero if the
+// provider does not tell.
+func (c *Credentials) expiration() time.Time {
+	if e, ok := c.provider.(expirer); ok {
+		return e.ExpiresAt()
+	}
+	return time.Time{}
+}
+
+// expiringEvent returns the event warning the cached credentials are
+// about to expire, once per retrieved credentials.
+func (c *Credentials) expiringEvent() []Event {
+	if c.expiryWarning <= 0 || c.warned {
+		return nil
+	}
+	expiration := c.expiration()
+	if expiration.IsZero() || expiration.Sub(time.Now()) > c.expiryWarning {
+		return nil
+	}
+	c.warned = true
+	return []Event{{
+		Type:        EventExpiring,
+		AccessKeyID: c.creds.AccessKeyID,
+		Expiration:  expiration,
+	}}
+}
@@ -0,0 +1,149 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstoragetest
+
+import (
+	"sort"
+	"strings"
+
+	minio "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// Maximum number of keys returned by a single ListObjectsPage call.
+const maxListKeys = 1000
+
+// ListObjectsPage lists one page of objects in bucketName with the
+// semantics of a single list objects request: keys are returned in
+// lexical order after marker, keys containing delimiter after prefix
+// are rolled up into common prefixes, and at most maxKeys entries are
+// returned.
+func (s *Storage) ListObjectsPage(bucketName, objectPrefix, marker, delimiter string, maxKeys int) (minio.ListBucketResult, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return minio.ListBucketResult{}, err
+	}
+	if err := s3utils.CheckValidObjectNamePrefix(objectPrefix); err != nil {
+		return minio.ListBucketResult{}, err
+	}
+	if maxKeys <= 0 || maxKeys > maxListKeys {
+		maxKeys = maxListKeys
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	b, err := s.getBucket(bucketName)
+	if err != nil {
+		return minio.ListBucketResult{}, err
+	}
+
+	keys := make([]string, 0, len(b.objects))
+	for key := range b.objects {
+		if strings.HasPrefix(key, objectPrefix) && key > marker {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := minio.ListBucketResult{
+		Name:      bucketName,
+		Prefix:    objectPrefix,
+		Marker:    marker,
+		Delimiter: delimiter,
+		MaxKeys:   int64(maxKeys),
+	}
+	var lastEntry string
+	for _, key := range keys {
+		commonPrefix := ""
+		if delimiter != "" {
+			if i := strings.Index(key[len(objectPrefix):], delimiter); i >= 0 {
+				commonPrefix = key[:len(objectPrefix)+i+len(delimiter)]
+			}
+		}
+		// All keys under a common prefix already returned are skipped,
+		// also when the marker itself is such a prefix.
+		if commonPrefix != "" && (commonPrefix == lastEntry || commonPrefix == marker) {
+			continue
+		}
+		if len(result.Contents)+len(result.CommonPrefixes) == maxKeys {
+			result.IsTruncated = true
+			break
+		}
+		if commonPrefix != "" {
+			result.CommonPrefixes = append(result.CommonPrefixes, minio.CommonPrefix{Prefix: commonPrefix})
+			lastEntry = commonPrefix
+			continue
+		}
+		result.Contents = append(result.Contents, b.objects[key].info)
+		lastEntry = key
+	}
+	if result.IsTruncated {
+		result.NextMarker = lastEntry
+	}
+	return result, nil
+}
+
+// ListObjects lists all objects in bucketName starting with
+// objectPrefix, paging through ListObjectsPage.
+func (s *Storage) ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	objectStatCh := make(chan minio.ObjectInfo, 1)
+
+	delimiter := "/"
+	if recursive {
+		delimiter = ""
+	}
+
+	go func() {
+		defer close(objectStatCh)
+		marker := ""
+		for {
+			result, err := s.ListObjectsPage(bucketName, objectPrefix, marker, delimiter, maxListKeys)
+			if err != nil {
+				select {
+				case objectStatCh <- minio.ObjectInfo{Err: err}:
+				case <-doneCh:
+				}
+				return
+			}
+			for _, object := range result.Contents {
+				select {
+				case objectStatCh <- object:
+				case <-doneCh:
+					return
+				}
+			}
+			for _, obj := range result.CommonPrefixes {
+				select {
+				case objectStatCh <- minio.ObjectInfo{Key: obj.Prefix, Size: 0}:
+				case <-doneCh:
+					return
+				}
+			}
+			if !result.IsTruncated {
+				return
+			}
+			marker = result.NextMarker
+		}
+	}()
+	return objectStatCh
+}
+
+// ListObjectsV2 lists all objects in bucketName starting with
+// objectPrefix, it behaves exactly like ListObjects.
+func (s *Storage) ListObjectsV2(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	return s.ListObjects(bucketName, objectPrefix, recursive, doneCh)
+}
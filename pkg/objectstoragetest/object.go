@@ -0,0 +1,358 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstoragetest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	minio "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// Prefix of user defined metadata keys.
+const amzMetaPrefix = "X-Amz-Meta-"
+
+// PutObject stores all data read from reader as objectName.
+func (s *Storage) PutObject(bucketName, objectName string, reader io.Reader, contentType string) (n int64, err error) {
+	metaData := make(map[string][]string)
+	if contentType != "" {
+		metaData["Content-Type"] = []string{contentType}
+	}
+	return s.PutObjectWithMetadata(bucketName, objectName, reader, metaData, nil)
+}
+
+// PutObjectWithMetadata stores all data read from reader as objectName
+// along with metaData. Data and metadata only become visible once the
+// reader is exhausted, just like on the server.
+func (s *Storage) PutObjectWithMetadata(bucketName, objectName string, reader io.Reader, metaData map[string][]string, progress io.Reader) (n int64, err error) {
+	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+		return 0, err
+	}
+	if err = s3utils.CheckValidObjectName(objectName); err != nil {
+		return 0, err
+	}
+	if reader == nil {
+		return 0, minio.ErrInvalidArgument("Input reader is invalid, cannot be nil.")
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return 0, err
+	}
+	if progress != nil {
+		if _, err = io.CopyN(ioutil.Discard, progress, int64(len(data))); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = s.putObject(bucketName, newObject(objectName, data, metaData)); err != nil {
+		return 0, err
+	}
+	return int64(len(data)), nil
+}
+
+// putObject stores obj in bucketName replacing any previous object
+// with the same name.
+func (s *Storage) putObject(bucketName string, obj *object) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := s.getBucket(bucketName)
+	if err != nil {
+		return err
+	}
+	b.objects[obj.info.Key] = obj
+	return nil
+}
+
+// FPutObject stores the contents of filePath as objectName.
+func (s *Storage) FPutObject(bucketName, objectName, filePath, contentType string) (n int64, err error) {
+	fileReader, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer fileReader.Close()
+	return s.PutObject(bucketName, objectName, fileReader, contentType)
+}
+
+// GetObject returns a seekable, readable object behaving like the
+// ones returned by minio.Client.
+func (s *Storage) GetObject(bucketName, objectName string) (*minio.Object, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return nil, err
+	}
+	return minio.NewObjectReader(objectSource{
+		storage:    s,
+		bucketName: bucketName,
+		objectName: objectName,
+	}), nil
+}
+
+// FGetObject writes the contents of objectName to filePath.
+func (s *Storage) FGetObject(bucketName, objectName, filePath string) error {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+
+	s.mutex.RLock()
+	obj, err := s.getObject(bucketName, objectName)
+	s.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, obj.data, 0600)
+}
+
+// StatObject returns the metadata of objectName.
+func (s *Storage) StatObject(bucketName, objectName string) (minio.ObjectInfo, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return minio.ObjectInfo{}, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return minio.ObjectInfo{}, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	obj, err := s.getObject(bucketName, objectName)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+	return obj.info, nil
+}
+
+// CopyObject copies objectSource, in the form "bucket/object", to
+// objectName. Copy conditions cannot be inspected outside package
+// minio, hence cpCond is ignored.
+func (s *Storage) CopyObject(bucketName string, objectName string, objectSource string, cpCond minio.CopyConditions) error {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+	source := strings.SplitN(strings.TrimPrefix(objectSource, "/"), "/", 2)
+	if len(source) != 2 {
+		return minio.ErrInvalidArgument("Object source should be of the form bucket/object.")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	srcObj, err := s.getObject(source[0], source[1])
+	if err != nil {
+		return err
+	}
+	b, err := s.getBucket(bucketName)
+	if err != nil {
+		return err
+	}
+	info := srcObj.info
+	info.Key = objectName
+	info.LastModified = time.Now().UTC()
+	b.objects[objectName] = &object{
+		data: srcObj.data,
+		info: info,
+	}
+	return nil
+}
+
+// RemoveObject deletes objectName, removing a missing object is
+// not an error.
+func (s *Storage) RemoveObject(bucketName, objectName string) error {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := s.getBucket(bucketName)
+	if err != nil {
+		return err
+	}
+	delete(b.objects, objectName)
+	return nil
+}
+
+// RemoveObjects deletes all object names received from objectsCh,
+// errors are sent on the returned channel which is closed once
+// objectsCh is closed and drained.
+func (s *Storage) RemoveObjects(bucketName string, objectsCh <-chan string) <-chan minio.RemoveObjectError {
+	errorCh := make(chan minio.RemoveObjectError, 1)
+	go func() {
+		defer close(errorCh)
+		for objectName := range objectsCh {
+			if err := s.RemoveObject(bucketName, objectName); err != nil {
+				errorCh <- minio.RemoveObjectError{
+					ObjectName: objectName,
+					Err:        err,
+				}
+			}
+		}
+	}()
+	return errorCh
+}
+
+// newObject builds an object from data and the metadata supplied
+// at upload.
+func newObject(objectName string, data []byte, metaData map[string][]string) *object {
+	sum := md5.Sum(data)
+	info := minio.ObjectInfo{
+		ETag:         hex.EncodeToString(sum[:]),
+		Key:          objectName,
+		LastModified: time.Now().UTC(),
+		Size:         int64(len(data)),
+		ContentType:  "application/octet-stream",
+		Metadata:     make(http.Header),
+		StorageClass: "STANDARD",
+	}
+	for k, v := range metaData {
+		if len(v) == 0 {
+			continue
+		}
+		k = http.CanonicalHeaderKey(k)
+		switch {
+		case k == "Content-Type":
+			info.ContentType = v[0]
+			continue
+		case k == "Content-Encoding":
+			info.ContentEncoding = v[0]
+		case k == "Content-Disposition":
+			info.ContentDisposition = v[0]
+		case k == "Content-Language":
+			info.ContentLanguage = v[0]
+		case k == "Cache-Control":
+			info.CacheControl = v[0]
+		case strings.HasPrefix(k, amzMetaPrefix):
+			if info.UserMetadata == nil {
+				info.UserMetadata = make(map[string]string)
+			}
+			info.UserMetadata[k[len(amzMetaPrefix):]] = v[0]
+		}
+		info.Metadata[k] = v
+	}
+	return &object{
+		data: data,
+		info: info,
+	}
+}
+
+// objectSource serves ranged reads of a stored object to
+// minio.Object.
+type objectSource struct {
+	storage    *Storage
+	bucketName string
+	objectName string
+}
+
+func (o objectSource) GetObject(reqHeaders minio.RequestHeaders) (io.ReadCloser, minio.ObjectInfo, error) {
+	obj, err := o.object(reqHeaders)
+	if err != nil {
+		return nil, minio.ObjectInfo{}, err
+	}
+	start, end, err := parseRange(reqHeaders.Get("Range"), obj.info.Size)
+	if err != nil {
+		return nil, minio.ObjectInfo{}, errorResponse("InvalidRange", err.Error(), o.bucketName, o.objectName)
+	}
+	return ioutil.NopCloser(bytes.NewReader(obj.data[start:end])), obj.info, nil
+}
+
+func (o objectSource) StatObject(reqHeaders minio.RequestHeaders) (minio.ObjectInfo, error) {
+	obj, err := o.object(reqHeaders)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+	return obj.info, nil
+}
+
+// object looks up the stored object and verifies the If-Match
+// precondition, if any.
+func (o objectSource) object(reqHeaders minio.RequestHeaders) (*object, error) {
+	o.storage.mutex.RLock()
+	obj, err := o.storage.getObject(o.bucketName, o.objectName)
+	o.storage.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if etag := strings.Trim(reqHeaders.Get("If-Match"), "\""); etag != "" && etag != obj.info.ETag {
+		return nil, errorResponse("PreconditionFailed", "At least one of the preconditions you specified did not hold.", o.bucketName, o.objectName)
+	}
+	return obj, nil
+}
+
+// parseRange returns the start and end offsets selected by a
+// "bytes=" range header for an object of size bytes. Offsets are
+// half open, the whole object is selected if rangeHeader is empty.
+func parseRange(rangeHeader string, size int64) (start, end int64, err error) {
+	if rangeHeader == "" {
+		return 0, size, nil
+	}
+	spec := strings.TrimPrefix(rangeHeader, "bytes=")
+	dash := strings.Index(spec, "-")
+	if spec == rangeHeader || dash < 0 {
+		return 0, 0, minio.ErrInvalidArgument("Invalid range " + rangeHeader)
+	}
+	if dash == 0 {
+		// Last N bytes, "bytes=-N".
+		var n int64
+		if n, err = strconv.ParseInt(spec[1:], 10, 64); err != nil {
+			return 0, 0, err
+		}
+		if n > size {
+			n = size
+		}
+		return size - n, size, nil
+	}
+	if start, err = strconv.ParseInt(spec[:dash], 10, 64); err != nil {
+		return 0, 0, err
+	}
+	end = size - 1
+	if spec[dash+1:] != "" {
+		if end, err = strconv.ParseInt(spec[dash+1:], 10, 64); err != nil {
+			return 0, 0, err
+		}
+	}
+	if start >= size || end < start {
+		return 0, 0, minio.ErrInvalidArgument("The requested range is not satisfiable")
+	}
+	if end >= size {
+		end = size - 1
+	}
+	return start, end + 1, nil
+}
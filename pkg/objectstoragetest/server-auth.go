@@ -0,0 +1,187 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstoragetest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// Signature related constants.
+const (
+	signV4Algorithm   = "AWS4-HMAC-SHA256"
+	iso8601DateFormat = "20060102T150405Z"
+	yyyymmdd          = "20060102"
+	unsignedPayload   = "UNSIGNED-PAYLOAD"
+	streamingPayload  = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+)
+
+// Maximum allowed difference between request and server time.
+const maxClockSkew = 15 * time.Minute
+
+// Errors returned for failed authentication.
+var (
+	errAccessDenied       = errorResponse("AccessDenied", "Access Denied.", "", "")
+	errInvalidAccessKeyID = errorResponse("InvalidAccessKeyId", "The access key ID you provided does not exist in our records.", "", "")
+	errSignatureMismatch  = errorResponse("SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided. Check your key and signing method.", "", "")
+	errRequestTimeSkewed  = errorResponse("RequestTimeTooSkewed", "The difference between the request time and the server's time is too large.", "", "")
+	errExpiredPresign     = errorResponse("AccessDenied", "Request has expired", "", "")
+)
+
+// authenticate verifies the request signature. Signature V4 requests,
+// presigned or not, are fully verified while signature V2 requests
+// are only checked for the access key. Chunk signatures of streaming
+// uploads are not verified.
+func (s *Server) authenticate(r *http.Request) error {
+	if s.accessKey == "" && s.secretKey == "" {
+		return nil
+	}
+	query := r.URL.Query()
+	auth := r.Header.Get("Authorization")
+	switch {
+	case strings.HasPrefix(auth, signV4Algorithm+" "):
+		return s.verifyV4(r, parseAuthFields(strings.TrimPrefix(auth, signV4Algorithm+" ")), false)
+	case query.Get("X-Amz-Algorithm") == signV4Algorithm:
+		return s.verifyV4(r, map[string]string{
+			"Credential":    query.Get("X-Amz-Credential"),
+			"SignedHeaders": query.Get("X-Amz-SignedHeaders"),
+			"Signature":     query.Get("X-Amz-Signature"),
+		}, true)
+	case strings.HasPrefix(auth, "AWS "):
+		accessKey := strings.TrimPrefix(auth, "AWS ")
+		if i := strings.LastIndex(accessKey, ":"); i >= 0 {
+			accessKey = accessKey[:i]
+		}
+		if accessKey != s.accessKey {
+			return errInvalidAccessKeyID
+		}
+		return nil
+	case query.Get("AWSAccessKeyId") != "":
+		if query.Get("AWSAccessKeyId") != s.accessKey {
+			return errInvalidAccessKeyID
+		}
+		return nil
+	}
+	return errAccessDenied
+}
+
+// parseAuthFields parses the comma separated key=value fields of a
+// signature V4 authorization header.
+func parseAuthFields(auth string) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range strings.Split(auth, ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	return fields
+}
+
+// verifyV4 recomputes the signature V4 of the request and compares
+// it against the one provided.
+func (s *Server) verifyV4(r *http.Request, fields map[string]string, presigned bool) error {
+	// Credential is of the form <access-key>/<date>/<region>/s3/aws4_request.
+	credential := strings.Split(fields["Credential"], "/")
+	if len(credential) != 5 || credential[3] != "s3" || credential[4] != "aws4_request" {
+		return errAccessDenied
+	}
+	if credential[0] != s.accessKey {
+		return errInvalidAccessKeyID
+	}
+	region := credential[2]
+
+	query := r.URL.Query()
+	dateStr := r.Header.Get("X-Amz-Date")
+	if presigned {
+		dateStr = query.Get("X-Amz-Date")
+	}
+	t, err := time.Parse(iso8601DateFormat, dateStr)
+	if err != nil || t.Format(yyyymmdd) != credential[1] {
+		return errAccessDenied
+	}
+
+	hashedPayload := r.Header.Get("X-Amz-Content-Sha256")
+	if presigned {
+		expires, err := strconv.ParseInt(query.Get("X-Amz-Expires"), 10, 64)
+		if err != nil {
+			return errAccessDenied
+		}
+		if time.Now().UTC().After(t.Add(time.Duration(expires) * time.Second)) {
+			return errExpiredPresign
+		}
+		query.Del("X-Amz-Signature")
+		hashedPayload = unsignedPayload
+	} else {
+		now := time.Now().UTC()
+		if t.After(now.Add(maxClockSkew)) || t.Before(now.Add(-maxClockSkew)) {
+			return errRequestTimeSkewed
+		}
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	sort.Strings(signedHeaders)
+	var canonicalHeaders []string
+	for _, k := range signedHeaders {
+		value := strings.Join(r.Header[http.CanonicalHeaderKey(k)], ",")
+		if k == "host" {
+			value = r.Host
+		}
+		canonicalHeaders = append(canonicalHeaders, k+":"+value+"\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		s3utils.EncodePath(r.URL.Path),
+		strings.Replace(query.Encode(), "+", "%20", -1),
+		strings.Join(canonicalHeaders, ""),
+		strings.Join(signedHeaders, ";"),
+		hashedPayload,
+	}, "\n")
+
+	scope := strings.Join(credential[1:], "/")
+	canonicalRequestSum := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := signV4Algorithm + "\n" + dateStr + "\n" + scope + "\n" +
+		hex.EncodeToString(canonicalRequestSum[:])
+
+	signingKey := sumHMAC([]byte("AWS4"+s.secretKey), []byte(credential[1]))
+	signingKey = sumHMAC(signingKey, []byte(region))
+	signingKey = sumHMAC(signingKey, []byte("s3"))
+	signingKey = sumHMAC(signingKey, []byte("aws4_request"))
+	signature := hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
+
+	if !hmac.Equal([]byte(signature), []byte(fields["Signature"])) {
+		return errSignatureMismatch
+	}
+	return nil
+}
+
+// sumHMAC calculates the hmac of data with key.
+func sumHMAC(key []byte, data []byte) []byte {
+	hash := hmac.New(sha256.New, key)
+	hash.Write(data)
+	return hash.Sum(nil)
+}
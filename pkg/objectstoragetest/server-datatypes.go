@@ -0,0 +1,214 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstoragetest
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// listAllMyBucketsResult container for list buckets response.
+type listAllMyBucketsResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Owner   owner
+	Buckets struct {
+		Bucket []bucketEntry
+	}
+}
+
+// owner container for bucket and object owner.
+type owner struct {
+	ID          string
+	DisplayName string
+}
+
+// bucketEntry container for a single bucket in list buckets response.
+type bucketEntry struct {
+	Name         string
+	CreationDate time.Time
+}
+
+// locationConstraint container for get bucket location response.
+type locationConstraint struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+	Location string   `xml:",chardata"`
+}
+
+// createBucketConfiguration container for make bucket request.
+type createBucketConfiguration struct {
+	Location string `xml:"LocationConstraint"`
+}
+
+// objectEntry container for a single object in list objects response.
+type objectEntry struct {
+	Key          string
+	LastModified time.Time
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+// commonPrefix container for prefix in list objects response.
+type commonPrefix struct {
+	Prefix string
+}
+
+// listBucketResult container for list objects response.
+type listBucketResult struct {
+	XMLName        xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name           string
+	Prefix         string
+	Marker         string
+	NextMarker     string `xml:",omitempty"`
+	MaxKeys        int
+	Delimiter      string `xml:",omitempty"`
+	EncodingType   string `xml:",omitempty"`
+	IsTruncated    bool
+	Contents       []objectEntry
+	CommonPrefixes []commonPrefix
+}
+
+// listBucketV2Result container for list objects version 2 response.
+type listBucketV2Result struct {
+	XMLName               xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name                  string
+	Prefix                string
+	StartAfter            string `xml:",omitempty"`
+	ContinuationToken     string `xml:",omitempty"`
+	NextContinuationToken string `xml:",omitempty"`
+	KeyCount              int
+	MaxKeys               int
+	Delimiter             string `xml:",omitempty"`
+	EncodingType          string `xml:",omitempty"`
+	IsTruncated           bool
+	Contents              []objectEntry
+	CommonPrefixes        []commonPrefix
+}
+
+// initiateMultipartUploadResult container for new multipart upload
+// response.
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ InitiateMultipartUploadResult"`
+	Bucket   string
+	Key      string
+	UploadID string `xml:"UploadId"`
+}
+
+// completePart container for a single part in complete multipart
+// upload request.
+type completePart struct {
+	PartNumber int
+	ETag       string
+}
+
+// completeMultipartUpload container for complete multipart upload
+// request.
+type completeMultipartUpload struct {
+	Parts []completePart `xml:"Part"`
+}
+
+// completeMultipartUploadResult container for complete multipart
+// upload response.
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
+	Location string
+	Bucket   string
+	Key      string
+	ETag     string
+}
+
+// partEntry container for a single part in list parts response.
+type partEntry struct {
+	PartNumber   int
+	LastModified time.Time
+	ETag         string
+	Size         int64
+}
+
+// listPartsResult container for list parts response.
+type listPartsResult struct {
+	XMLName              xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListPartsResult"`
+	Bucket               string
+	Key                  string
+	UploadID             string `xml:"UploadId"`
+	StorageClass         string
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+	IsTruncated          bool
+	Parts                []partEntry `xml:"Part"`
+}
+
+// uploadEntry container for a single upload in list multipart
+// uploads response.
+type uploadEntry struct {
+	Key          string
+	UploadID     string `xml:"UploadId"`
+	StorageClass string
+	Initiated    time.Time
+}
+
+// listMultipartUploadsResult container for list multipart uploads
+// response.
+type listMultipartUploadsResult struct {
+	XMLName            xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListMultipartUploadsResult"`
+	Bucket             string
+	KeyMarker          string
+	UploadIDMarker     string `xml:"UploadIdMarker"`
+	NextKeyMarker      string
+	NextUploadIDMarker string `xml:"NextUploadIdMarker"`
+	MaxUploads         int
+	IsTruncated        bool
+	Prefix             string
+	EncodingType       string        `xml:",omitempty"`
+	Uploads            []uploadEntry `xml:"Upload"`
+}
+
+// copyObjectResult container for copy object and upload part copy
+// responses.
+type copyObjectResult struct {
+	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyObjectResult"`
+	ETag         string
+	LastModified time.Time
+}
+
+// deleteObject container for a single object in multi delete request.
+type deleteObject struct {
+	Key string
+}
+
+// deleteMultiObjects container for multi delete request.
+type deleteMultiObjects struct {
+	Quiet   bool
+	Objects []deleteObject `xml:"Object"`
+}
+
+// deleteError container for a failed deletion in multi delete
+// response.
+type deleteError struct {
+	Key     string
+	Code    string
+	Message string
+}
+
+// deleteMultiObjectsResult container for multi delete response.
+type deleteMultiObjectsResult struct {
+	XMLName xml.Name       `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult"`
+	Deleted []deleteObject `xml:"Deleted"`
+	Errors  []deleteError  `xml:"Error"`
+}
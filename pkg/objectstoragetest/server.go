@@ -0,0 +1,1014 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstoragetest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	minio "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// Server is a local S3 compatible server backed by a Storage. It
+// covers the subset of the S3 API issued by minio.Client: bucket and
+// object operations, multipart uploads and listing. Requests must be
+// signed with the server credentials unless the server is anonymous.
+// Only path style requests are supported.
+type Server struct {
+	*httptest.Server
+
+	// Storage backing the server, objects stored directly are
+	// visible through the server and vice versa.
+	Storage *Storage
+
+	accessKey string
+	secretKey string
+
+	mutex    *sync.Mutex
+	uploads  map[string]*multipartUpload
+	failures []injectedFailure
+	requests int
+}
+
+// multipartUpload holds the parts of an incomplete multipart upload.
+type multipartUpload struct {
+	bucketName string
+	objectName string
+	metaData   map[string][]string
+	initiated  time.Time
+	parts      map[int]*object
+}
+
+// injectedFailure is an error response returned instead of serving
+// the request.
+type injectedFailure struct {
+	statusCode int
+	code       string
+}
+
+// NewServer starts a new server with an empty Storage. If accessKey
+// and secretKey are empty, requests are not authenticated. The
+// caller should call Close when finished to shut it down.
+func NewServer(accessKey, secretKey string) *Server {
+	s := &Server{
+		Storage:   New(),
+		accessKey: accessKey,
+		secretKey: secretKey,
+		mutex:     &sync.Mutex{},
+		uploads:   make(map[string]*multipartUpload),
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Endpoint returns the host:port of the server, suitable for
+// minio.New with secure set to false.
+func (s *Server) Endpoint() string {
+	return s.Listener.Addr().String()
+}
+
+// FailNext makes the next count requests fail with statusCode and
+// the S3 error code, useful to test retries and error parsing.
+func (s *Server) FailNext(count, statusCode int, code string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := 0; i < count; i++ {
+		s.failures = append(s.failures, injectedFailure{
+			statusCode: statusCode,
+			code:       code,
+		})
+	}
+}
+
+// Requests returns the number of requests received so far,
+// including failed ones.
+func (s *Server) Requests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests
+}
+
+// ServeHTTP serves a single S3 request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	s.requests++
+	var failure *injectedFailure
+	if len(s.failures) > 0 {
+		failure = &s.failures[0]
+		s.failures = s.failures[1:]
+	}
+	s.mutex.Unlock()
+
+	if failure != nil {
+		writeError(w, r, failure.statusCode, minio.ErrorResponse{
+			Code:    failure.code,
+			Message: "Injected failure.",
+		})
+		return
+	}
+
+	if err := s.authenticate(r); err != nil {
+		writeErrorResponse(w, r, err)
+		return
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		writeErrorResponse(w, r, err)
+		return
+	}
+
+	bucketName, objectName := splitPath(r.URL.Path)
+	if bucketName != "" {
+		if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+			writeErrorResponse(w, r, minio.ErrInvalidBucketName(err.Error()))
+			return
+		}
+	}
+	if objectName != "" {
+		if err = s3utils.CheckValidObjectName(objectName); err != nil {
+			writeErrorResponse(w, r, minio.ErrInvalidObjectName(err.Error()))
+			return
+		}
+	}
+	switch {
+	case bucketName == "":
+		err = s.serveService(w, r)
+	case objectName == "":
+		err = s.serveBucket(w, r, bucketName, body)
+	default:
+		err = s.serveObject(w, r, bucketName, objectName, body)
+	}
+	if err != nil {
+		writeErrorResponse(w, r, err)
+	}
+}
+
+// serveService serves requests without a bucket.
+func (s *Server) serveService(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != "GET" {
+		return errorResponse("MethodNotAllowed", "The specified method is not allowed against this resource.", "", "")
+	}
+	buckets, err := s.Storage.ListBuckets()
+	if err != nil {
+		return err
+	}
+	result := listAllMyBucketsResult{}
+	result.Owner.ID = s.accessKey
+	result.Owner.DisplayName = s.accessKey
+	for _, bucket := range buckets {
+		result.Buckets.Bucket = append(result.Buckets.Bucket, bucketEntry{
+			Name:         bucket.Name,
+			CreationDate: bucket.CreationDate,
+		})
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+// serveBucket serves bucket level requests.
+func (s *Server) serveBucket(w http.ResponseWriter, r *http.Request, bucketName string, body []byte) error {
+	query := r.URL.Query()
+	switch r.Method {
+	case "PUT":
+		if len(query) > 0 {
+			return errNotImplemented
+		}
+		location := ""
+		if len(body) > 0 {
+			config := createBucketConfiguration{}
+			if err := xml.Unmarshal(body, &config); err != nil {
+				return errMalformedXML
+			}
+			location = config.Location
+		}
+		if err := s.Storage.MakeBucket(bucketName, location); err != nil {
+			return err
+		}
+		w.Header().Set("Location", "/"+bucketName)
+		w.WriteHeader(http.StatusOK)
+		return nil
+	case "HEAD":
+		if err := s.checkBucket(bucketName); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusOK)
+		return nil
+	case "DELETE":
+		if err := s.Storage.RemoveBucket(bucketName); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	case "POST":
+		if _, ok := query["delete"]; ok {
+			return s.deleteMultipleObjects(w, bucketName, body)
+		}
+		return errNotImplemented
+	case "GET":
+		switch {
+		case hasQuery(query, "location"):
+			return s.getBucketLocation(w, bucketName)
+		case hasQuery(query, "uploads"):
+			return s.listMultipartUploads(w, bucketName, query)
+		case query.Get("list-type") == "2":
+			return s.listObjectsV2(w, bucketName, query)
+		case hasOnlyQuery(query, "prefix", "marker", "delimiter", "max-keys", "encoding-type"):
+			return s.listObjects(w, bucketName, query)
+		}
+		return errNotImplemented
+	}
+	return errorResponse("MethodNotAllowed", "The specified method is not allowed against this resource.", bucketName, "")
+}
+
+// serveObject serves object level requests.
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, bucketName, objectName string, body []byte) error {
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+	switch r.Method {
+	case "PUT":
+		switch {
+		case uploadID != "" && r.Header.Get("X-Amz-Copy-Source") != "":
+			return s.uploadPartCopy(w, r, bucketName, objectName, uploadID)
+		case uploadID != "":
+			return s.uploadPart(w, r, bucketName, objectName, uploadID, body)
+		case r.Header.Get("X-Amz-Copy-Source") != "":
+			return s.copyObject(w, r, bucketName, objectName)
+		case hasOnlyQuery(query):
+			return s.putObject(w, r, bucketName, objectName, body)
+		}
+		return errNotImplemented
+	case "POST":
+		switch {
+		case hasQuery(query, "uploads"):
+			return s.newMultipartUpload(w, r, bucketName, objectName)
+		case uploadID != "":
+			return s.completeMultipartUpload(w, bucketName, objectName, uploadID, body)
+		}
+		return errNotImplemented
+	case "GET", "HEAD":
+		if uploadID != "" && r.Method == "GET" {
+			return s.listParts(w, bucketName, objectName, uploadID)
+		}
+		if !hasOnlyQuery(query, "response-content-type", "response-content-language",
+			"response-expires", "response-cache-control", "response-content-disposition",
+			"response-content-encoding") {
+			return errNotImplemented
+		}
+		return s.getObject(w, r, bucketName, objectName)
+	case "DELETE":
+		if uploadID != "" {
+			return s.abortMultipartUpload(w, bucketName, objectName, uploadID)
+		}
+		if err := s.Storage.RemoveObject(bucketName, objectName); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	return errorResponse("MethodNotAllowed", "The specified method is not allowed against this resource.", bucketName, objectName)
+}
+
+// checkBucket verifies if bucketName exists.
+func (s *Server) checkBucket(bucketName string) error {
+	s.Storage.mutex.RLock()
+	defer s.Storage.mutex.RUnlock()
+	_, err := s.Storage.getBucket(bucketName)
+	return err
+}
+
+func (s *Server) getBucketLocation(w http.ResponseWriter, bucketName string) error {
+	s.Storage.mutex.RLock()
+	b, err := s.Storage.getBucket(bucketName)
+	s.Storage.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	result := locationConstraint{}
+	// Buckets in us-east-1 report an empty location constraint.
+	if b.location != "us-east-1" {
+		result.Location = b.location
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, bucketName string, query url.Values) error {
+	maxKeys, err := parseMaxKeys(query.Get("max-keys"))
+	if err != nil {
+		return err
+	}
+	page, err := s.Storage.ListObjectsPage(bucketName, query.Get("prefix"), query.Get("marker"), query.Get("delimiter"), maxKeys)
+	if err != nil {
+		return err
+	}
+	result := listBucketResult{
+		Name:        bucketName,
+		Prefix:      page.Prefix,
+		Marker:      page.Marker,
+		NextMarker:  page.NextMarker,
+		MaxKeys:     int(page.MaxKeys),
+		Delimiter:   page.Delimiter,
+		IsTruncated: page.IsTruncated,
+	}
+	result.Contents, result.CommonPrefixes = listEntries(page)
+	if query.Get("encoding-type") == "url" {
+		result.EncodingType = "url"
+		encodeKeys(&result.Prefix, &result.Marker, &result.NextMarker, &result.Delimiter)
+		encodeEntries(result.Contents, result.CommonPrefixes)
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+func (s *Server) listObjectsV2(w http.ResponseWriter, bucketName string, query url.Values) error {
+	maxKeys, err := parseMaxKeys(query.Get("max-keys"))
+	if err != nil {
+		return err
+	}
+	// Continuation tokens are the last returned entry, which makes
+	// them interchangeable with markers.
+	marker := query.Get("start-after")
+	if token := query.Get("continuation-token"); token != "" {
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return minio.ErrInvalidArgument("The continuation token provided is incorrect.")
+		}
+		marker = string(decoded)
+	}
+	page, err := s.Storage.ListObjectsPage(bucketName, query.Get("prefix"), marker, query.Get("delimiter"), maxKeys)
+	if err != nil {
+		return err
+	}
+	result := listBucketV2Result{
+		Name:              bucketName,
+		Prefix:            page.Prefix,
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: query.Get("continuation-token"),
+		MaxKeys:           int(page.MaxKeys),
+		Delimiter:         page.Delimiter,
+		IsTruncated:       page.IsTruncated,
+	}
+	if page.IsTruncated {
+		result.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(page.NextMarker))
+	}
+	result.Contents, result.CommonPrefixes = listEntries(page)
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+	if query.Get("encoding-type") == "url" {
+		result.EncodingType = "url"
+		encodeKeys(&result.Prefix, &result.StartAfter, &result.Delimiter)
+		encodeEntries(result.Contents, result.CommonPrefixes)
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+// listEntries converts a listing page into response entries.
+func listEntries(page minio.ListBucketResult) (contents []objectEntry, prefixes []commonPrefix) {
+	for _, objInfo := range page.Contents {
+		contents = append(contents, objectEntry{
+			Key:          objInfo.Key,
+			LastModified: objInfo.LastModified,
+			ETag:         "\"" + objInfo.ETag + "\"",
+			Size:         objInfo.Size,
+			StorageClass: objInfo.StorageClass,
+		})
+	}
+	for _, prefix := range page.CommonPrefixes {
+		prefixes = append(prefixes, commonPrefix{Prefix: prefix.Prefix})
+	}
+	return contents, prefixes
+}
+
+// encodeKeys url encodes keys in place, as requested by encoding-type=url.
+func encodeKeys(keys ...*string) {
+	for _, key := range keys {
+		*key = url.QueryEscape(*key)
+	}
+}
+
+// encodeEntries url encodes the keys of listing entries in place.
+func encodeEntries(contents []objectEntry, prefixes []commonPrefix) {
+	for i := range contents {
+		encodeKeys(&contents[i].Key)
+	}
+	for i := range prefixes {
+		encodeKeys(&prefixes[i].Prefix)
+	}
+}
+
+func (s *Server) deleteMultipleObjects(w http.ResponseWriter, bucketName string, body []byte) error {
+	if err := s.checkBucket(bucketName); err != nil {
+		return err
+	}
+	request := deleteMultiObjects{}
+	if err := xml.Unmarshal(body, &request); err != nil {
+		return errMalformedXML
+	}
+	result := deleteMultiObjectsResult{}
+	for _, obj := range request.Objects {
+		if err := s.Storage.RemoveObject(bucketName, obj.Key); err != nil {
+			errResp := minio.ToErrorResponse(err)
+			result.Errors = append(result.Errors, deleteError{
+				Key:     obj.Key,
+				Code:    errResp.Code,
+				Message: errResp.Message,
+			})
+			continue
+		}
+		if !request.Quiet {
+			result.Deleted = append(result.Deleted, obj)
+		}
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+func (s *Server) putObject(w http.ResponseWriter, r *http.Request, bucketName, objectName string, body []byte) error {
+	obj := newObject(objectName, body, requestMetadata(r.Header))
+	if err := s.Storage.putObject(bucketName, obj); err != nil {
+		return err
+	}
+	w.Header().Set("ETag", "\""+obj.info.ETag+"\"")
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) getObject(w http.ResponseWriter, r *http.Request, bucketName, objectName string) error {
+	s.Storage.mutex.RLock()
+	obj, err := s.Storage.getObject(bucketName, objectName)
+	s.Storage.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	if err = checkPreconditions(r.Header, "", obj, bucketName, objectName); err != nil {
+		if err == errNotModified {
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+		return err
+	}
+
+	header := w.Header()
+	for k, v := range obj.info.Metadata {
+		header[k] = v
+	}
+	header.Set("ETag", "\""+obj.info.ETag+"\"")
+	header.Set("Last-Modified", obj.info.LastModified.Format(http.TimeFormat))
+	header.Set("Content-Type", obj.info.ContentType)
+	header.Set("Accept-Ranges", "bytes")
+	for k, v := range r.URL.Query() {
+		if strings.HasPrefix(k, "response-") {
+			header.Set(strings.TrimPrefix(k, "response-"), v[0])
+		}
+	}
+
+	statusCode := http.StatusOK
+	start, end := int64(0), obj.info.Size
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		if start, end, err = parseRange(rangeHeader, obj.info.Size); err != nil {
+			return errorResponse("InvalidRange", "The requested range is not satisfiable", bucketName, objectName)
+		}
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, obj.info.Size))
+		statusCode = http.StatusPartialContent
+	}
+	header.Set("Content-Length", strconv.FormatInt(end-start, 10))
+	w.WriteHeader(statusCode)
+	if r.Method == "GET" {
+		w.Write(obj.data[start:end])
+	}
+	return nil
+}
+
+func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, bucketName, objectName string) error {
+	srcObj, err := s.copySource(r)
+	if err != nil {
+		return err
+	}
+	obj := &object{
+		data: srcObj.data,
+		info: srcObj.info,
+	}
+	obj.info.Key = objectName
+	obj.info.LastModified = time.Now().UTC()
+	if r.Header.Get("X-Amz-Metadata-Directive") == "REPLACE" {
+		obj = newObject(objectName, srcObj.data, requestMetadata(r.Header))
+	}
+	if err = s.Storage.putObject(bucketName, obj); err != nil {
+		return err
+	}
+	return writeXML(w, http.StatusOK, copyObjectResult{
+		ETag:         "\"" + obj.info.ETag + "\"",
+		LastModified: obj.info.LastModified,
+	})
+}
+
+// copySource returns the object named by the copy source header
+// after verifying the copy preconditions.
+func (s *Server) copySource(r *http.Request) (*object, error) {
+	source, err := url.QueryUnescape(r.Header.Get("X-Amz-Copy-Source"))
+	if err != nil {
+		return nil, minio.ErrInvalidArgument("Copy Source must mention the source bucket and key: sourcebucket/sourcekey.")
+	}
+	srcBucketName, srcObjectName := splitPath("/" + strings.TrimPrefix(source, "/"))
+	if srcBucketName == "" || srcObjectName == "" {
+		return nil, minio.ErrInvalidArgument("Copy Source must mention the source bucket and key: sourcebucket/sourcekey.")
+	}
+	s.Storage.mutex.RLock()
+	obj, err := s.Storage.getObject(srcBucketName, srcObjectName)
+	s.Storage.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if err = checkPreconditions(r.Header, "X-Amz-Copy-Source-", obj, srcBucketName, srcObjectName); err != nil {
+		if err == errNotModified {
+			err = errorResponse("PreconditionFailed", "At least one of the pre-conditions you specified did not hold", srcBucketName, srcObjectName)
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (s *Server) newMultipartUpload(w http.ResponseWriter, r *http.Request, bucketName, objectName string) error {
+	if err := s.checkBucket(bucketName); err != nil {
+		return err
+	}
+	uploadID, err := newUploadID()
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.uploads[uploadID] = &multipartUpload{
+		bucketName: bucketName,
+		objectName: objectName,
+		metaData:   requestMetadata(r.Header),
+		initiated:  time.Now().UTC(),
+		parts:      make(map[int]*object),
+	}
+	s.mutex.Unlock()
+	return writeXML(w, http.StatusOK, initiateMultipartUploadResult{
+		Bucket:   bucketName,
+		Key:      objectName,
+		UploadID: uploadID,
+	})
+}
+
+// getUpload returns the upload with uploadID, callers must hold the
+// mutex.
+func (s *Server) getUpload(bucketName, objectName, uploadID string) (*multipartUpload, error) {
+	upload, ok := s.uploads[uploadID]
+	if !ok || upload.bucketName != bucketName || upload.objectName != objectName {
+		return nil, errorResponse("NoSuchUpload", "The specified multipart upload does not exist.", bucketName, objectName)
+	}
+	return upload, nil
+}
+
+// addPart stores data as partNumber of the upload.
+func (s *Server) addPart(r *http.Request, bucketName, objectName, uploadID string, data []byte) (*object, error) {
+	partNumber, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > 10000 {
+		return nil, minio.ErrInvalidArgument("Part number must be an integer between 1 and 10000, inclusive.")
+	}
+	part := newObject(strconv.Itoa(partNumber), data, nil)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	upload, err := s.getUpload(bucketName, objectName, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	upload.parts[partNumber] = part
+	return part, nil
+}
+
+func (s *Server) uploadPart(w http.ResponseWriter, r *http.Request, bucketName, objectName, uploadID string, body []byte) error {
+	part, err := s.addPart(r, bucketName, objectName, uploadID, body)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("ETag", "\""+part.info.ETag+"\"")
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) uploadPartCopy(w http.ResponseWriter, r *http.Request, bucketName, objectName, uploadID string) error {
+	srcObj, err := s.copySource(r)
+	if err != nil {
+		return err
+	}
+	start, end := int64(0), srcObj.info.Size
+	if rangeHeader := r.Header.Get("X-Amz-Copy-Source-Range"); rangeHeader != "" {
+		if start, end, err = parseRange(rangeHeader, srcObj.info.Size); err != nil {
+			return minio.ErrInvalidArgument("The x-amz-copy-source-range value must be of the form bytes=first-last where first and last are the zero-based offsets of the first and last bytes to copy")
+		}
+	}
+	part, err := s.addPart(r, bucketName, objectName, uploadID, srcObj.data[start:end])
+	if err != nil {
+		return err
+	}
+	return writeXML(w, http.StatusOK, copyObjectResult{
+		ETag:         "\"" + part.info.ETag + "\"",
+		LastModified: part.info.LastModified,
+	})
+}
+
+func (s *Server) completeMultipartUpload(w http.ResponseWriter, bucketName, objectName, uploadID string, body []byte) error {
+	request := completeMultipartUpload{}
+	if err := xml.Unmarshal(body, &request); err != nil {
+		return errMalformedXML
+	}
+	if len(request.Parts) == 0 {
+		return errMalformedXML
+	}
+
+	s.mutex.Lock()
+	upload, err := s.getUpload(bucketName, objectName, uploadID)
+	if err != nil {
+		s.mutex.Unlock()
+		return err
+	}
+	var data []byte
+	var md5s []byte
+	for i, part := range request.Parts {
+		if i > 0 && part.PartNumber <= request.Parts[i-1].PartNumber {
+			s.mutex.Unlock()
+			return errorResponse("InvalidPartOrder", "The list of parts was not in ascending order.", bucketName, objectName)
+		}
+		uploaded, ok := upload.parts[part.PartNumber]
+		if !ok || strings.Trim(part.ETag, "\"") != uploaded.info.ETag {
+			s.mutex.Unlock()
+			return errorResponse("InvalidPart", "One or more of the specified parts could not be found.", bucketName, objectName)
+		}
+		sum, _ := hex.DecodeString(uploaded.info.ETag)
+		md5s = append(md5s, sum...)
+		data = append(data, uploaded.data...)
+	}
+	delete(s.uploads, uploadID)
+	s.mutex.Unlock()
+
+	obj := newObject(objectName, data, upload.metaData)
+	sum := md5.Sum(md5s)
+	obj.info.ETag = hex.EncodeToString(sum[:]) + "-" + strconv.Itoa(len(request.Parts))
+	if err = s.Storage.putObject(bucketName, obj); err != nil {
+		return err
+	}
+	return writeXML(w, http.StatusOK, completeMultipartUploadResult{
+		Location: "/" + bucketName + "/" + objectName,
+		Bucket:   bucketName,
+		Key:      objectName,
+		ETag:     "\"" + obj.info.ETag + "\"",
+	})
+}
+
+func (s *Server) abortMultipartUpload(w http.ResponseWriter, bucketName, objectName, uploadID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.getUpload(bucketName, objectName, uploadID); err != nil {
+		return err
+	}
+	delete(s.uploads, uploadID)
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) listParts(w http.ResponseWriter, bucketName, objectName, uploadID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	upload, err := s.getUpload(bucketName, objectName, uploadID)
+	if err != nil {
+		return err
+	}
+	partNumbers := make([]int, 0, len(upload.parts))
+	for partNumber := range upload.parts {
+		partNumbers = append(partNumbers, partNumber)
+	}
+	sort.Ints(partNumbers)
+	result := listPartsResult{
+		Bucket:       bucketName,
+		Key:          objectName,
+		UploadID:     uploadID,
+		StorageClass: "STANDARD",
+		MaxParts:     10000,
+	}
+	for _, partNumber := range partNumbers {
+		part := upload.parts[partNumber]
+		result.Parts = append(result.Parts, partEntry{
+			PartNumber:   partNumber,
+			LastModified: part.info.LastModified,
+			ETag:         "\"" + part.info.ETag + "\"",
+			Size:         part.info.Size,
+		})
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+// listMultipartUploads lists all incomplete uploads in bucketName,
+// delimiters are not supported.
+func (s *Server) listMultipartUploads(w http.ResponseWriter, bucketName string, query url.Values) error {
+	if err := s.checkBucket(bucketName); err != nil {
+		return err
+	}
+	prefix := query.Get("prefix")
+	result := listMultipartUploadsResult{
+		Bucket:     bucketName,
+		Prefix:     prefix,
+		MaxUploads: 1000,
+	}
+	s.mutex.Lock()
+	for uploadID, upload := range s.uploads {
+		if upload.bucketName != bucketName || !strings.HasPrefix(upload.objectName, prefix) {
+			continue
+		}
+		result.Uploads = append(result.Uploads, uploadEntry{
+			Key:          upload.objectName,
+			UploadID:     uploadID,
+			StorageClass: "STANDARD",
+			Initiated:    upload.initiated,
+		})
+	}
+	s.mutex.Unlock()
+	sort.Sort(uploadsByKey(result.Uploads))
+	if query.Get("encoding-type") == "url" {
+		result.EncodingType = "url"
+		encodeKeys(&result.Prefix)
+		for i := range result.Uploads {
+			encodeKeys(&result.Uploads[i].Key)
+		}
+	}
+	return writeXML(w, http.StatusOK, result)
+}
+
+// uploadsByKey sorts uploads by object name and initiation time.
+type uploadsByKey []uploadEntry
+
+func (u uploadsByKey) Len() int      { return len(u) }
+func (u uploadsByKey) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u uploadsByKey) Less(i, j int) bool {
+	if u[i].Key != u[j].Key {
+		return u[i].Key < u[j].Key
+	}
+	return u[i].Initiated.Before(u[j].Initiated)
+}
+
+// Errors returned for unsupported or malformed requests.
+var (
+	errNotImplemented = errorResponse("NotImplemented", "A header or query you provided implies functionality that is not implemented.", "", "")
+	errMalformedXML   = errorResponse("MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema.", "", "")
+	// errNotModified is returned by checkPreconditions for failed
+	// If-None-Match and If-Modified-Since conditions.
+	errNotModified = errors.New("Not Modified")
+)
+
+// checkPreconditions verifies the conditional headers, optionally
+// prefixed like X-Amz-Copy-Source-If-Match, against obj.
+func checkPreconditions(header http.Header, prefix string, obj *object, bucketName, objectName string) error {
+	failed := errorResponse("PreconditionFailed", "At least one of the pre-conditions you specified did not hold", bucketName, objectName)
+	if etag := header.Get(prefix + "If-Match"); etag != "" && strings.Trim(etag, "\"") != obj.info.ETag {
+		return failed
+	}
+	if etag := header.Get(prefix + "If-None-Match"); etag != "" && strings.Trim(etag, "\"") == obj.info.ETag {
+		return errNotModified
+	}
+	lastModified := obj.info.LastModified.Truncate(time.Second)
+	if t, err := time.Parse(http.TimeFormat, header.Get(prefix+"If-Unmodified-Since")); err == nil && lastModified.After(t) {
+		return failed
+	}
+	if t, err := time.Parse(http.TimeFormat, header.Get(prefix+"If-Modified-Since")); err == nil && !lastModified.After(t) {
+		return errNotModified
+	}
+	return nil
+}
+
+// requestMetadata returns the object metadata supplied in request
+// headers.
+func requestMetadata(header http.Header) map[string][]string {
+	metaData := make(map[string][]string)
+	for k, v := range header {
+		k = http.CanonicalHeaderKey(k)
+		switch {
+		case k == "Content-Type", k == "Content-Encoding", k == "Content-Disposition",
+			k == "Content-Language", k == "Cache-Control", k == "Expires",
+			strings.HasPrefix(k, amzMetaPrefix), strings.HasPrefix(k, "X-Amz-Object-Lock-"),
+			k == "X-Amz-Storage-Class":
+			metaData[k] = v
+		}
+	}
+	return metaData
+}
+
+// readBody reads the request body verifying the Content-Md5 and
+// X-Amz-Content-Sha256 headers, streaming signed bodies are decoded.
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	contentSHA256 := r.Header.Get("X-Amz-Content-Sha256")
+	switch contentSHA256 {
+	case "", unsignedPayload:
+	case streamingPayload:
+		if body, err = decodeChunkedBody(body); err != nil {
+			return nil, err
+		}
+	default:
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != contentSHA256 {
+			return nil, errorResponse("XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed.", "", "")
+		}
+	}
+	if contentMD5 := r.Header.Get("Content-Md5"); contentMD5 != "" {
+		sum := md5.Sum(body)
+		if base64.StdEncoding.EncodeToString(sum[:]) != contentMD5 {
+			return nil, errorResponse("BadDigest", "The Content-Md5 you specified did not match what we received.", "", "")
+		}
+	}
+	return body, nil
+}
+
+// decodeChunkedBody decodes an aws-chunked body, chunk signatures
+// are not verified.
+func decodeChunkedBody(body []byte) ([]byte, error) {
+	var data []byte
+	for {
+		i := bytes.Index(body, []byte("\r\n"))
+		if i < 0 {
+			return nil, errorResponse("IncompleteBody", "You did not provide the number of bytes specified by the Content-Length HTTP header.", "", "")
+		}
+		chunkHeader := string(body[:i])
+		if j := strings.Index(chunkHeader, ";"); j >= 0 {
+			chunkHeader = chunkHeader[:j]
+		}
+		size, err := strconv.ParseInt(chunkHeader, 16, 64)
+		if err != nil || int64(len(body)-i-2) < size+2 {
+			return nil, errorResponse("IncompleteBody", "You did not provide the number of bytes specified by the Content-Length HTTP header.", "", "")
+		}
+		if size == 0 {
+			return data, nil
+		}
+		data = append(data, body[i+2:i+2+int(size)]...)
+		body = body[i+2+int(size)+2:]
+	}
+}
+
+// splitPath splits a path style request path into bucket and
+// object names.
+func splitPath(urlPath string) (bucketName, objectName string) {
+	parts := strings.SplitN(strings.TrimPrefix(urlPath, "/"), "/", 2)
+	bucketName = parts[0]
+	if len(parts) == 2 {
+		objectName = parts[1]
+	}
+	return bucketName, objectName
+}
+
+// hasQuery verifies if query has key.
+func hasQuery(query url.Values, key string) bool {
+	_, ok := query[key]
+	return ok
+}
+
+// hasOnlyQuery verifies if query only has keys out of the allowed ones,
+// presigned request parameters are always allowed.
+func hasOnlyQuery(query url.Values, allowed ...string) bool {
+	for k := range query {
+		if strings.HasPrefix(k, "X-Amz-") || k == "AWSAccessKeyId" || k == "Signature" || k == "Expires" {
+			continue
+		}
+		found := false
+		for _, key := range allowed {
+			if k == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// parseMaxKeys parses the max-keys query parameter.
+func parseMaxKeys(value string) (int, error) {
+	if value == "" {
+		return maxListKeys, nil
+	}
+	maxKeys, err := strconv.Atoi(value)
+	if err != nil || maxKeys < 0 {
+		return 0, minio.ErrInvalidArgument("Argument maxKeys must be an integer between 0 and 2147483647")
+	}
+	return maxKeys, nil
+}
+
+// newUploadID generates a random upload id.
+func newUploadID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// writeXML writes v as the XML response body.
+func writeXML(w http.ResponseWriter, statusCode int, v interface{}) error {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(xml.Header)+len(data)))
+	w.WriteHeader(statusCode)
+	io.WriteString(w, xml.Header)
+	w.Write(data)
+	return nil
+}
+
+// Status codes of the error codes returned by the server.
+var errorStatusCodes = map[string]int{
+	"AccessDenied":              http.StatusForbidden,
+	"BadDigest":                 http.StatusBadRequest,
+	"BucketAlreadyOwnedByYou":   http.StatusConflict,
+	"BucketNotEmpty":            http.StatusConflict,
+	"IncompleteBody":            http.StatusBadRequest,
+	"InvalidAccessKeyId":        http.StatusForbidden,
+	"InvalidArgument":           http.StatusBadRequest,
+	"InvalidBucketName":         http.StatusBadRequest,
+	"InvalidPart":               http.StatusBadRequest,
+	"InvalidPartOrder":          http.StatusBadRequest,
+	"InvalidRange":              http.StatusRequestedRangeNotSatisfiable,
+	"MalformedXML":              http.StatusBadRequest,
+	"MethodNotAllowed":          http.StatusMethodNotAllowed,
+	"NoSuchBucket":              http.StatusNotFound,
+	"NoSuchKey":                 http.StatusNotFound,
+	"NoSuchUpload":              http.StatusNotFound,
+	"NotImplemented":            http.StatusNotImplemented,
+	"PreconditionFailed":        http.StatusPreconditionFailed,
+	"RequestTimeTooSkewed":      http.StatusForbidden,
+	"SignatureDoesNotMatch":     http.StatusForbidden,
+	"XAmzContentSHA256Mismatch": http.StatusBadRequest,
+}
+
+// writeErrorResponse writes err as an S3 error response.
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	errResp, ok := err.(minio.ErrorResponse)
+	if !ok {
+		errResp = minio.ErrorResponse{
+			Code:    "InternalError",
+			Message: err.Error(),
+		}
+	}
+	statusCode, ok := errorStatusCodes[errResp.Code]
+	if !ok {
+		statusCode = http.StatusInternalServerError
+	}
+	writeError(w, r, statusCode, errResp)
+}
+
+// writeError writes errResp with statusCode, HEAD responses have
+// no body.
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, errResp minio.ErrorResponse) {
+	bucketName, objectName := splitPath(r.URL.Path)
+	if errResp.BucketName == "" {
+		errResp.BucketName = bucketName
+	}
+	if errResp.Key == "" {
+		errResp.Key = objectName
+	}
+	if errResp.RequestID == "" {
+		errResp.RequestID = strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	w.Header().Set("x-amz-request-id", errResp.RequestID)
+	if r.Method == "HEAD" {
+		w.WriteHeader(statusCode)
+		return
+	}
+	writeXML(w, statusCode, errResp)
+}
@@ -0,0 +1,579 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstoragetest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go"
+)
+
+const (
+	testAccessKey = "Q3AM3UQ867SPQQA43P2F"
+	testSecretKey = "zuf+tfteSlswRu7BJ86wekitnifILbZam1KYY3TG"
+)
+
+// newTestClient returns a client connected to a new server.
+func newTestClient(t *testing.T) (*Server, *minio.Client) {
+	server := NewServer(testAccessKey, testSecretKey)
+	clnt, err := minio.NewV4(server.Endpoint(), testAccessKey, testSecretKey, false)
+	if err != nil {
+		server.Close()
+		t.Fatal("Error:", err)
+	}
+	return server, clnt
+}
+
+// Tests bucket and object operations issued by the client.
+func TestServerOperations(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+
+	if err := clnt.MakeBucket("bucket", "us-east-1"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if ok, err := clnt.BucketExists("bucket"); err != nil || !ok {
+		t.Fatalf("Expected bucket to exist, got %t %v", ok, err)
+	}
+	if ok, err := clnt.BucketExists("missing"); err != nil || ok {
+		t.Fatalf("Expected bucket to be missing, got %t %v", ok, err)
+	}
+
+	data := []byte("hello, world")
+	metaData := map[string][]string{
+		"Content-Type":    {"text/plain"},
+		"X-Amz-Meta-User": {"minio"},
+	}
+	if _, err := clnt.PutObjectWithMetadata("bucket", "dir/object", bytes.NewReader(data), metaData, nil); err != nil {
+		t.Fatal("Error:", err)
+	}
+	objInfo, err := clnt.StatObject("bucket", "dir/object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if objInfo.Size != int64(len(data)) || objInfo.ContentType != "text/plain" || objInfo.UserMetadata["User"] != "minio" {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+
+	r, err := clnt.GetObject("bucket", "dir/object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	buf := make([]byte, 5)
+	if _, err = r.ReadAt(buf, 7); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if string(buf) != "world" {
+		t.Fatalf("Expected world, got %q", buf)
+	}
+	r.Close()
+
+	if err = clnt.CopyObject("bucket", "copy", "bucket/dir/object", minio.NewCopyConditions()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	cpCond := minio.NewCopyConditions()
+	cpCond.SetMatchETag("mismatch")
+	if err = clnt.CopyObject("bucket", "copy", "bucket/dir/object", cpCond); minio.ToErrorResponse(err).Code != "PreconditionFailed" {
+		t.Fatalf("Expected PreconditionFailed, got %v", err)
+	}
+
+	var keys []string
+	for objInfo := range clnt.ListObjectsV2("bucket", "", false, nil) {
+		if objInfo.Err != nil {
+			t.Fatal("Error:", objInfo.Err)
+		}
+		keys = append(keys, objInfo.Key)
+	}
+	if strings.Join(keys, ",") != "copy,dir/" {
+		t.Fatalf("Unexpected listing %v", keys)
+	}
+
+	objectsCh := make(chan string, 2)
+	objectsCh <- "copy"
+	objectsCh <- "dir/object"
+	close(objectsCh)
+	for rErr := range clnt.RemoveObjects("bucket", objectsCh) {
+		t.Fatal("Error:", rErr.Err)
+	}
+	if _, err = clnt.StatObject("bucket", "copy"); minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		t.Fatalf("Expected NoSuchKey, got %v", err)
+	}
+	if err = clnt.RemoveBucket("bucket"); err != nil {
+		t.Fatal("Error:", err)
+	}
+}
+
+// Tests listings keep keys with characters invalid in XML intact.
+func TestServerListingEncoding(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+
+	if err := clnt.MakeBucket("bucket", "us-east-1"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	names := []string{"dir\x01/%41", "dir\x01/a+b c", "top\x1f"}
+	for _, name := range names {
+		if _, err := clnt.PutObject("bucket", name, strings.NewReader("data"), "text/plain"); err != nil {
+			t.Fatal("Error:", err)
+		}
+	}
+
+	for _, useV1 := range []bool{false, true} {
+		var listed []string
+		opts := minio.ListObjectsOptions{Prefix: "dir\x01/", Recursive: true, UseV1: useV1}
+		for objInfo := range clnt.ListObjectsWithOptions("bucket", opts, nil) {
+			if objInfo.Err != nil {
+				t.Fatal("Error:", objInfo.Err)
+			}
+			listed = append(listed, objInfo.Key)
+		}
+		if strings.Join(listed, ",") != strings.Join(names[:2], ",") {
+			t.Fatalf("V1 %t: unexpected listing %q", useV1, listed)
+		}
+
+		listed = nil
+		opts = minio.ListObjectsOptions{UseV1: useV1}
+		for objInfo := range clnt.ListObjectsWithOptions("bucket", opts, nil) {
+			if objInfo.Err != nil {
+				t.Fatal("Error:", objInfo.Err)
+			}
+			listed = append(listed, objInfo.Key)
+		}
+		if strings.Join(listed, ",") != "top\x1f,dir\x01/" {
+			t.Fatalf("V1 %t: unexpected delimited listing %q", useV1, listed)
+		}
+	}
+}
+
+// Tests interrupted downloads resume from their part file.
+func TestServerFGetObjectResume(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+
+	if err := clnt.MakeBucket("bucket", "us-east-1"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	data := "hello, world"
+	if _, err := clnt.PutObject("bucket", "object", strings.NewReader(data), "text/plain"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	objInfo, err := clnt.StatObject("bucket", "object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	dir, err := ioutil.TempDir("", "objectstoragetest")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "object")
+	filePartPath := filePath + objInfo.ETag + ".part.minio"
+
+	testCases := []struct {
+		part     string
+		opts     minio.FGetObjectOptions
+		expected string
+	}{
+		// Resumed on size alone, the partial download is kept as is.
+		{"HELLO", minio.FGetObjectOptions{}, "HELLO, world"},
+		// Tail does not match, downloaded again.
+		{"HELLO", minio.FGetObjectOptions{VerifyTail: 2}, data},
+		// Tail matches, resumed.
+		{"HEllo", minio.FGetObjectOptions{VerifyTail: 2}, "HEllo, world"},
+		// Larger than the object, downloaded again.
+		{data + "!", minio.FGetObjectOptions{}, data},
+		// Complete, only renamed.
+		{data, minio.FGetObjectOptions{VerifyTail: 4}, data},
+	}
+	for i, testCase := range testCases {
+		if err = ioutil.WriteFile(filePartPath, []byte(testCase.part), 0600); err != nil {
+			t.Fatal("Error:", err)
+		}
+		if err = clnt.FGetObjectWithOptions("bucket", "object", filePath, testCase.opts); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		if string(content) != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, content)
+		}
+	}
+}
+
+// Tests objects under a prefix are downloaded to a directory.
+func TestServerDownloadPrefix(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+
+	if err := clnt.MakeBucket("bucket", "us-east-1"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	objects := map[string]string{
+		"photos/2017/":           "",
+		"photos/2017/a.jpg":      "a",
+		"photos/2017/jan/b.jpg":  "bb",
+		"photos/2017/jan/c.jpg":  "ccc",
+		"photos/2017/feb/":       "",
+		"photos/2017/../../evil": "evil",
+		"photos/2018/d.jpg":      "dddd",
+	}
+	for objectName, data := range objects {
+		if _, err := clnt.PutObject("bucket", objectName, strings.NewReader(data), "image/jpeg"); err != nil {
+			t.Fatal("Error:", err)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "objectstoragetest")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	defer os.RemoveAll(dir)
+	localDir := filepath.Join(dir, "download")
+
+	var failed []string
+	for result := range clnt.DownloadPrefix("bucket", "photos/2017/", localDir, 2) {
+		if result.ObjectName == "photos/2017/" {
+			t.Errorf("Expected the directory marker of the prefix to be skipped, got %v", result)
+		}
+		if result.Err != nil {
+			failed = append(failed, result.ObjectName)
+			continue
+		}
+		if result.Size != int64(len(objects[result.ObjectName])) {
+			t.Errorf("Unexpected size %d of %s", result.Size, result.ObjectName)
+		}
+	}
+	if len(failed) != 1 || failed[0] != "photos/2017/../../evil" {
+		t.Errorf("Expected only the object escaping the directory to fail, got %v", failed)
+	}
+	expected := map[string]string{
+		"a.jpg":     "a",
+		"jan/b.jpg": "bb",
+		"jan/c.jpg": "ccc",
+	}
+	for path, data := range expected {
+		content, err := ioutil.ReadFile(filepath.Join(localDir, filepath.FromSlash(path)))
+		if err != nil || string(content) != data {
+			t.Errorf("Expected %s to hold %q, got %q, %v", path, data, content, err)
+		}
+	}
+	if st, err := os.Stat(filepath.Join(localDir, "feb")); err != nil || !st.IsDir() {
+		t.Errorf("Expected directory feb, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "evil")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be written outside of the directory, got %v", err)
+	}
+
+	// Relative directories such as "." are accepted.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	relDir := filepath.Join(dir, "relative")
+	if err = os.MkdirAll(relDir, 0700); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = os.Chdir(relDir); err != nil {
+		t.Fatal("Error:", err)
+	}
+	defer os.Chdir(wd)
+	for result := range clnt.DownloadPrefix("bucket", "photos/2017/jan/", ".", 1) {
+		if result.Err != nil {
+			t.Errorf("Unexpected error downloading %s, %v", result.ObjectName, result.Err)
+		}
+	}
+	if content, err := ioutil.ReadFile(filepath.Join(relDir, "b.jpg")); err != nil || string(content) != "bb" {
+		t.Errorf("Expected b.jpg to hold %q, got %q, %v", "bb", content, err)
+	}
+	if err = os.Chdir(wd); err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	// Listing failures are reported.
+	var results []minio.DownloadResult
+	for result := range clnt.DownloadPrefix("missing", "", localDir, 0) {
+		results = append(results, result)
+	}
+	if len(results) != 1 || minio.ToErrorResponse(results[0].Err).Code != "NoSuchBucket" {
+		t.Errorf("Expected NoSuchBucket, got %v", results)
+	}
+}
+
+// Tests multipart uploads through the core client.
+func TestServerMultipart(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+	core := minio.Core{Client: clnt}
+
+	if err := clnt.MakeBucket("bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	uploadID, err := core.NewMultipartUpload("bucket", "object", nil)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	var parts []minio.CompletePart
+	for i, data := range []string{"part one ", "part two"} {
+		part, err := core.PutObjectPart("bucket", "object", uploadID, i+1, int64(len(data)), strings.NewReader(data), nil, nil)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	result, err := core.ListObjectParts("bucket", "object", uploadID, 0, 1000)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(result.ObjectParts) != 2 {
+		t.Fatalf("Expected 2 parts, got %d", len(result.ObjectParts))
+	}
+	if err = core.CompleteMultipartUpload("bucket", "object", uploadID, parts); err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	objInfo, err := clnt.StatObject("bucket", "object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if !strings.HasSuffix(objInfo.ETag, "-2") || objInfo.Size != 17 {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+	if err = core.AbortMultipartUpload("bucket", "object", uploadID); minio.ToErrorResponse(err).Code != "NoSuchUpload" {
+		t.Fatalf("Expected NoSuchUpload, got %v", err)
+	}
+}
+
+// Tests signature verification of regular, streaming and presigned
+// requests.
+func TestServerSignature(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+
+	if err := clnt.MakeBucket("bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err := clnt.PutObjectStreaming("bucket", "streaming", strings.NewReader("streaming data")); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if objInfo, err := server.Storage.StatObject("bucket", "streaming"); err != nil || objInfo.Size != 14 {
+		t.Fatalf("Unexpected streaming object %#v %v", objInfo, err)
+	}
+
+	u, err := clnt.PresignedGetObject("bucket", "streaming", time.Minute, nil)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	resp, err := http.Get(u.String())
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK || string(data) != "streaming data" {
+		t.Fatalf("Unexpected presigned response %d %q %v", resp.StatusCode, data, err)
+	}
+	reqParams := make(url.Values)
+	reqParams.Set("response-content-disposition", `attachment; filename="data.txt"`)
+	reqParams.Set("response-content-type", "text/plain")
+	u, err = clnt.PresignedGetObject("bucket", "streaming", time.Minute, reqParams)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if resp, err = http.Get(u.String()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Disposition") != `attachment; filename="data.txt"` || resp.Header.Get("Content-Type") != "text/plain" {
+		t.Fatalf("Unexpected presigned response %d with headers %v", resp.StatusCode, resp.Header)
+	}
+	// Overrides are part of the signature and cannot be altered.
+	query := u.Query()
+	query.Set("response-content-type", "text/html")
+	u.RawQuery = query.Encode()
+	if resp, err = http.Get(u.String()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("Expected altered presigned URL to be rejected, got %d", resp.StatusCode)
+	}
+
+	u, err = clnt.PresignedHeadObject("bucket", "streaming", time.Minute, nil)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	resp, err = http.Head(u.String())
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength != 14 {
+		t.Fatalf("Unexpected presigned HEAD response %d of length %d", resp.StatusCode, resp.ContentLength)
+	}
+	// A presigned GET URL cannot be used for HEAD.
+	u, err = clnt.PresignedGetObject("bucket", "streaming", time.Minute, nil)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if resp, err = http.Head(u.String()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("Expected presigned GET URL to be rejected for HEAD, got %d", resp.StatusCode)
+	}
+
+	badClnt, err := minio.NewV4(server.Endpoint(), testAccessKey, "bad-secret-key", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = badClnt.ListBuckets(); minio.ToErrorResponse(err).Code != "SignatureDoesNotMatch" {
+		t.Fatalf("Expected SignatureDoesNotMatch, got %v", err)
+	}
+	anonClnt, err := minio.New(server.Endpoint(), "", "", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = anonClnt.ListBuckets(); minio.ToErrorResponse(err).Code != "AccessDenied" {
+		t.Fatalf("Expected AccessDenied, got %v", err)
+	}
+}
+
+// Tests injected failures are retried or returned to the caller.
+func TestServerFailures(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+
+	if err := clnt.MakeBucket("bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	// Retryable failures are retried transparently.
+	server.FailNext(1, http.StatusServiceUnavailable, "SlowDown")
+	requests := server.Requests()
+	if _, err := clnt.ListBuckets(); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if n := server.Requests() - requests; n != 2 {
+		t.Fatalf("Expected 2 requests, got %d", n)
+	}
+
+	// Other failures are parsed into error responses.
+	server.FailNext(1, http.StatusForbidden, "AccessDenied")
+	_, err := clnt.ListBuckets()
+	errResp := minio.ToErrorResponse(err)
+	if errResp.Code != "AccessDenied" || errResp.Message != "Injected failure." {
+		t.Fatalf("Unexpected error %#v", errResp)
+	}
+}
+
+// Tests requests for buckets with an alternate endpoint are sent and
+// signed as configured.
+func TestServerBucketEndpoint(t *testing.T) {
+	server, clnt := newTestClient(t)
+	defer server.Close()
+	if err := clnt.MakeBucket("bucket", "us-east-1"); err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	// A custom domain serving its own copy of the bucket, requests
+	// are signed for it.
+	domain := NewServer(testAccessKey, testSecretKey)
+	defer domain.Close()
+	if err := domain.Storage.MakeBucket("bucket", "us-east-1"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err := domain.Storage.PutObject("bucket", "object", strings.NewReader("domain"), "text/plain"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err := clnt.SetBucketEndpoint("bucket", minio.BucketEndpoint{URL: domain.URL + "/bucket", ReadOnly: true}); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err := clnt.StatObject("bucket", "object"); err != nil {
+		t.Fatal("Expected object to be served by the alternate endpoint:", err)
+	}
+	// Writes go to the origin.
+	if _, err := clnt.PutObject("bucket", "object", strings.NewReader("origin"), "text/plain"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if objInfo, err := server.Storage.StatObject("bucket", "object"); err != nil || objInfo.Size != int64(len("origin")) {
+		t.Fatalf("Expected object to be written to the origin, got %v, %v", objInfo, err)
+	}
+
+	// A CDN forwarding requests to the origin, requests are signed
+	// for the origin.
+	originURL, _ := url.Parse(server.URL)
+	var forwarded int
+	cdn := httptest.NewServer(&httputil.ReverseProxy{Director: func(r *http.Request) {
+		forwarded++
+		r.URL.Scheme = originURL.Scheme
+		r.URL.Host = originURL.Host
+		r.URL.Path = "/bucket/" + strings.TrimPrefix(r.URL.Path, "/assets/")
+		r.Host = originURL.Host
+	}})
+	defer cdn.Close()
+	if err := clnt.SetBucketEndpoint("bucket", minio.BucketEndpoint{URL: cdn.URL + "/assets/", SignCanonicalHost: true}); err != nil {
+		t.Fatal("Error:", err)
+	}
+	r, err := clnt.GetObject("bucket", "object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != "origin" || forwarded != 1 {
+		t.Fatalf("Expected object to be read through the CDN, got %q, %v after %d requests", data, err, forwarded)
+	}
+	presignedURL, err := clnt.PresignedGetObject("bucket", "object", time.Minute, nil)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if !strings.HasPrefix(presignedURL.String(), cdn.URL+"/assets/object?") {
+		t.Fatalf("Expected presigned URL through the CDN, got %s", presignedURL)
+	}
+	resp, err := http.Get(presignedURL.String())
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected presigned request through the CDN to succeed, got %s", resp.Status)
+	}
+
+	clnt.RemoveBucketEndpoint("bucket")
+	if _, err = clnt.StatObject("bucket", "object"); err != nil || forwarded != 2 {
+		t.Fatalf("Expected requests to go to the origin again, got %v after %d forwarded requests", err, forwarded)
+	}
+
+	if err = clnt.SetBucketEndpoint("bucket", minio.BucketEndpoint{URL: "cdn.example.com"}); err == nil {
+		t.Fatal("Expected relative endpoint URL to fail")
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package objectstoragetest provides a concurrency safe in-memory
+// implementation of minio.API for use in unit tests, so that code
+// depending on object storage can be tested without a live server.
+// It also provides Server, a local S3 compatible server backed by
+// the same storage, to test signing, retries and error parsing of
+// minio.Client fully offline.
+package objectstoragetest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	minio "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// Storage is an in-memory object storage. The zero value is not
+// usable, use New to allocate one.
+type Storage struct {
+	mutex   *sync.RWMutex
+	buckets map[string]*bucket
+}
+
+// bucket holds all the objects of a single bucket.
+type bucket struct {
+	creationDate time.Time
+	location     string
+	objects      map[string]*object
+}
+
+// object holds data and metadata of an object. Both are never
+// modified once stored, overwrites replace the whole object.
+type object struct {
+	data []byte
+	info minio.ObjectInfo
+}
+
+// Storage implements minio.API.
+var _ minio.API = &Storage{}
+
+// New returns an empty in-memory object storage.
+func New() *Storage {
+	return &Storage{
+		mutex:   &sync.RWMutex{},
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// MakeBucket creates a new bucket with bucketName.
+func (s *Storage) MakeBucket(bucketName string, location string) error {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	if location == "" {
+		location = "us-east-1"
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.buckets[bucketName]; ok {
+		return errorResponse("BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.", bucketName, "")
+	}
+	s.buckets[bucketName] = &bucket{
+		creationDate: time.Now().UTC(),
+		location:     location,
+		objects:      make(map[string]*object),
+	}
+	return nil
+}
+
+// ListBuckets lists all buckets sorted by name.
+func (s *Storage) ListBuckets() ([]minio.BucketInfo, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	buckets := make([]minio.BucketInfo, 0, len(names))
+	for _, name := range names {
+		buckets = append(buckets, minio.BucketInfo{
+			Name:         name,
+			CreationDate: s.buckets[name].creationDate,
+		})
+	}
+	return buckets, nil
+}
+
+// BucketExists verifies if bucketName exists.
+func (s *Storage) BucketExists(bucketName string) (bool, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return false, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	_, ok := s.buckets[bucketName]
+	return ok, nil
+}
+
+// RemoveBucket deletes bucketName, the bucket must be empty.
+func (s *Storage) RemoveBucket(bucketName string) error {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := s.getBucket(bucketName)
+	if err != nil {
+		return err
+	}
+	if len(b.objects) > 0 {
+		return errorResponse("BucketNotEmpty", "The bucket you tried to delete is not empty.", bucketName, "")
+	}
+	delete(s.buckets, bucketName)
+	return nil
+}
+
+// getBucket returns bucketName, callers must hold the mutex.
+func (s *Storage) getBucket(bucketName string) (*bucket, error) {
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		return nil, errorResponse("NoSuchBucket", "The specified bucket does not exist.", bucketName, "")
+	}
+	return b, nil
+}
+
+// getObject returns objectName in bucketName, callers must hold
+// the mutex.
+func (s *Storage) getObject(bucketName, objectName string) (*object, error) {
+	b, err := s.getBucket(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := b.objects[objectName]
+	if !ok {
+		return nil, errorResponse("NoSuchKey", "The specified key does not exist.", bucketName, objectName)
+	}
+	return obj, nil
+}
+
+// errorResponse returns an error like the ones returned by the server.
+func errorResponse(code, message, bucketName, objectName string) error {
+	return minio.ErrorResponse{
+		Code:       code,
+		Message:    message,
+		BucketName: bucketName,
+		Key:        objectName,
+	}
+}
@@ -0,0 +1,274 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstoragetest
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	minio "github.com/minio/minio-go"
+)
+
+// Tests bucket operations.
+func TestBuckets(t *testing.T) {
+	var storage minio.API = New()
+	for _, bucketName := range []string{"bucket-b", "bucket-a"} {
+		if err := storage.MakeBucket(bucketName, ""); err != nil {
+			t.Fatal("Error:", err)
+		}
+	}
+	if err := storage.MakeBucket("bucket-a", ""); minio.ToErrorResponse(err).Code != "BucketAlreadyOwnedByYou" {
+		t.Fatalf("Expected BucketAlreadyOwnedByYou, got %v", err)
+	}
+	if err := storage.MakeBucket("B", ""); err == nil {
+		t.Fatal("Error: invalid bucket name must fail")
+	}
+
+	buckets, err := storage.ListBuckets()
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if len(buckets) != 2 || buckets[0].Name != "bucket-a" || buckets[1].Name != "bucket-b" {
+		t.Fatalf("Unexpected buckets %v", buckets)
+	}
+
+	if _, err = storage.PutObject("bucket-a", "object", strings.NewReader("data"), ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if err = storage.RemoveBucket("bucket-a"); minio.ToErrorResponse(err).Code != "BucketNotEmpty" {
+		t.Fatalf("Expected BucketNotEmpty, got %v", err)
+	}
+	if err = storage.RemoveBucket("bucket-b"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if ok, _ := storage.BucketExists("bucket-b"); ok {
+		t.Fatal("Error: removed bucket must not exist")
+	}
+	if err = storage.RemoveBucket("bucket-b"); minio.ToErrorResponse(err).Code != "NoSuchBucket" {
+		t.Fatalf("Expected NoSuchBucket, got %v", err)
+	}
+}
+
+// Tests object upload, download, stat, copy and removal.
+func TestObjects(t *testing.T) {
+	var storage minio.API = New()
+	if err := storage.MakeBucket("bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	data := []byte("hello, world")
+	metaData := map[string][]string{
+		"Content-Type":    {"text/plain"},
+		"x-amz-meta-user": {"minio"},
+	}
+	n, err := storage.PutObjectWithMetadata("bucket", "dir/object", bytes.NewReader(data), metaData, nil)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("Expected %d bytes, got %d", len(data), n)
+	}
+
+	objInfo, err := storage.StatObject("bucket", "dir/object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if objInfo.Size != int64(len(data)) || objInfo.ContentType != "text/plain" || objInfo.UserMetadata["User"] != "minio" {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+	if objInfo.ETag != "e4d7f1b4ed2e42d15898f4b27b019da4" {
+		t.Fatalf("Unexpected ETag %s", objInfo.ETag)
+	}
+
+	r, err := storage.GetObject("bucket", "dir/object")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if !bytes.Equal(buf, data) {
+		t.Fatalf("Expected %q, got %q", data, buf)
+	}
+	r.Close()
+
+	if r, err = storage.GetObject("bucket", "dir/object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	buf = make([]byte, 5)
+	if _, err = r.ReadAt(buf, 7); err != nil && err != io.EOF {
+		t.Fatal("Error:", err)
+	}
+	if string(buf) != "world" {
+		t.Fatalf("Expected world, got %q", buf)
+	}
+	if _, err = r.Seek(-5, 2); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if buf, err = ioutil.ReadAll(r); err != nil || string(buf) != "world" {
+		t.Fatalf("Expected world after seek, got %q %v", buf, err)
+	}
+	stat, err := r.Stat()
+	if err != nil || stat.ETag != objInfo.ETag {
+		t.Fatalf("Unexpected stat %#v %v", stat, err)
+	}
+	r.Close()
+
+	if r, err = storage.GetObject("bucket", "missing"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = r.Stat(); minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		t.Fatalf("Expected NoSuchKey, got %v", err)
+	}
+
+	if err = storage.CopyObject("bucket", "copy", "bucket/dir/object", minio.NewCopyConditions()); err != nil {
+		t.Fatal("Error:", err)
+	}
+	copyInfo, err := storage.StatObject("bucket", "copy")
+	if err != nil || copyInfo.ETag != objInfo.ETag || copyInfo.Key != "copy" {
+		t.Fatalf("Unexpected copy %#v %v", copyInfo, err)
+	}
+
+	dir, err := ioutil.TempDir("", "objectstoragetest")
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "sub", "object")
+	if err = storage.FGetObject("bucket", "copy", filePath); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = storage.FPutObject("bucket", "fput", filePath, ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+
+	objectsCh := make(chan string)
+	go func() {
+		defer close(objectsCh)
+		for _, objectName := range []string{"copy", "fput", "missing"} {
+			objectsCh <- objectName
+		}
+	}()
+	for rErr := range storage.RemoveObjects("bucket", objectsCh) {
+		t.Fatal("Error:", rErr.Err)
+	}
+	if err = storage.RemoveObject("bucket", "dir/object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if _, err = storage.StatObject("bucket", "dir/object"); minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		t.Fatalf("Expected NoSuchKey, got %v", err)
+	}
+}
+
+// Tests listing semantics with prefixes, delimiters and markers.
+func TestListObjects(t *testing.T) {
+	storage := New()
+	if err := storage.MakeBucket("bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	for _, objectName := range []string{"a/1", "a/2", "a/b/3", "b", "c/4", "d"} {
+		if _, err := storage.PutObject("bucket", objectName, strings.NewReader(objectName), ""); err != nil {
+			t.Fatal("Error:", err)
+		}
+	}
+
+	testCases := []struct {
+		prefix    string
+		recursive bool
+		keys      []string
+	}{
+		{"", true, []string{"a/1", "a/2", "a/b/3", "b", "c/4", "d"}},
+		{"", false, []string{"b", "d", "a/", "c/"}},
+		{"a/", false, []string{"a/1", "a/2", "a/b/"}},
+		{"a/", true, []string{"a/1", "a/2", "a/b/3"}},
+		{"x", true, nil},
+	}
+	for i, testCase := range testCases {
+		doneCh := make(chan struct{})
+		var keys []string
+		for objInfo := range storage.ListObjects("bucket", testCase.prefix, testCase.recursive, doneCh) {
+			if objInfo.Err != nil {
+				t.Fatalf("Test %d: %v", i+1, objInfo.Err)
+			}
+			keys = append(keys, objInfo.Key)
+		}
+		close(doneCh)
+		if !reflect.DeepEqual(keys, testCase.keys) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.keys, keys)
+		}
+	}
+
+	// Page through with a small max keys.
+	var entries []string
+	marker := ""
+	for {
+		result, err := storage.ListObjectsPage("bucket", "", marker, "/", 2)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		for _, object := range result.Contents {
+			entries = append(entries, object.Key)
+		}
+		for _, prefix := range result.CommonPrefixes {
+			entries = append(entries, prefix.Prefix)
+		}
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextMarker
+	}
+	if expected := []string{"b", "a/", "d", "c/"}; !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %v, got %v", expected, entries)
+	}
+
+	for objInfo := range storage.ListObjects("missing", "", true, nil) {
+		if minio.ToErrorResponse(objInfo.Err).Code != "NoSuchBucket" {
+			t.Fatalf("Expected NoSuchBucket, got %v", objInfo.Err)
+		}
+	}
+}
+
+// Tests concurrent access.
+func TestConcurrentAccess(t *testing.T) {
+	storage := New()
+	if err := storage.MakeBucket("bucket", ""); err != nil {
+		t.Fatal("Error:", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				storage.PutObject("bucket", "object", strings.NewReader("data"), "")
+				storage.StatObject("bucket", "object")
+				for range storage.ListObjects("bucket", "", true, nil) {
+				}
+				storage.RemoveObject("bucket", "object")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,106 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import "encoding/json"
+
+// Builder - builds the access policy of a bucket from access levels
+// granted to anyone on prefixes of the bucket, sparing hand written
+// policy JSON.
+//
+//     bucketPolicy := policy.NewBuilder("mybucket").
+//         ReadOnly("public/").
+//         WriteOnly("uploads/").
+//         Policy()
+//
+type Builder struct {
+	bucketName string
+	statements []Statement
+}
+
+// NewBuilder - returns a builder of the access policy of bucketName,
+// granting no access.
+func NewBuilder(bucketName string) *Builder {
+	return &Builder{bucketName: bucketName}
+}
+
+// From - starts from an existing policy, whose statements are kept
+// unless prefixes they apply to are set again.
+func (b *Builder) From(bucketPolicy BucketAccessPolicy) *Builder {
+	b.statements = append([]Statement{}, bucketPolicy.Statements...)
+	return b
+}
+
+// ReadOnly - grants anyone listing and downloading objects under
+// prefixes, an empty prefix standing for the whole bucket.
+func (b *Builder) ReadOnly(prefixes ...string) *Builder {
+	return b.set(BucketPolicyReadOnly, prefixes)
+}
+
+// WriteOnly - grants anyone uploading and removing objects under
+// prefixes, an empty prefix standing for the whole bucket.
+func (b *Builder) WriteOnly(prefixes ...string) *Builder {
+	return b.set(BucketPolicyWriteOnly, prefixes)
+}
+
+// ReadWrite - grants anyone both read and write access under prefixes,
+// an empty prefix standing for the whole bucket.
+func (b *Builder) ReadWrite(prefixes ...string) *Builder {
+	return b.set(BucketPolicyReadWrite, prefixes)
+}
+
+// None - revokes the access granted under prefixes.
+func (b *Builder) None(prefixes ...string) *Builder {
+	return b.set(BucketPolicyNone, prefixes)
+}
+
+// set - sets the access level of prefixes, the whole bucket when no
+// prefix is given.
+func (b *Builder) set(policy BucketPolicy, prefixes []string) *Builder {
+	if len(prefixes) == 0 {
+		prefixes = []string{""}
+	}
+	for _, prefix := range prefixes {
+		b.statements = SetPolicy(b.statements, policy, b.bucketName, prefix)
+	}
+	return b
+}
+
+// Policy - returns the built bucket access policy.
+func (b *Builder) Policy() BucketAccessPolicy {
+	return BucketAccessPolicy{
+		Version:    "2012-10-17",
+		Statements: append([]Statement{}, b.statements...),
+	}
+}
+
+// JSON - returns the built bucket access policy as JSON.
+func (b *Builder) JSON() ([]byte, error) {
+	return json.Marshal(b.Policy())
+}
+
+// GetPoliciesFromJSON - returns the access levels granted on the
+// prefixes of bucketName by the bucket access policy in policyJSON,
+// keyed in the form returned by GetPolicies.
+func GetPoliciesFromJSON(policyJSON []byte, bucketName string) (map[string]BucketPolicy, error) {
+	bucketPolicy := BucketAccessPolicy{}
+	if err := json.Unmarshal(policyJSON, &bucketPolicy); err != nil {
+		return nil, err
+	}
+	return GetPolicies(bucketPolicy.Statements, bucketName), nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Tests policies built for prefixes grant the expected access levels.
+func TestBuilder(t *testing.T) {
+	builder := NewBuilder("mybucket").
+		ReadOnly("public/", "docs/").
+		WriteOnly("uploads/").
+		ReadWrite("shared/")
+
+	bucketPolicy := builder.Policy()
+	testCases := []struct {
+		prefix   string
+		expected BucketPolicy
+	}{
+		{"public/", BucketPolicyReadOnly},
+		{"docs/", BucketPolicyReadOnly},
+		{"uploads/", BucketPolicyWriteOnly},
+		{"shared/", BucketPolicyReadWrite},
+		{"private/", BucketPolicyNone},
+	}
+	for i, testCase := range testCases {
+		if policy := GetPolicy(bucketPolicy.Statements, "mybucket", testCase.prefix); policy != testCase.expected {
+			t.Errorf("Test %d: expected %s on %s, got %s", i+1, testCase.expected, testCase.prefix, policy)
+		}
+	}
+
+	// Revoking access keeps the other prefixes.
+	bucketPolicy = NewBuilder("mybucket").From(bucketPolicy).None("docs/").Policy()
+	if policy := GetPolicy(bucketPolicy.Statements, "mybucket", "docs/"); policy != BucketPolicyNone {
+		t.Errorf("Expected access to docs/ revoked, got %s", policy)
+	}
+	if policy := GetPolicy(bucketPolicy.Statements, "mybucket", "public/"); policy != BucketPolicyReadOnly {
+		t.Errorf("Expected access to public/ kept, got %s", policy)
+	}
+
+	// No prefix grants access to the whole bucket.
+	bucketPolicy = NewBuilder("mybucket").ReadOnly().Policy()
+	if policy := GetPolicy(bucketPolicy.Statements, "mybucket", ""); policy != BucketPolicyReadOnly {
+		t.Errorf("Expected whole bucket read only, got %s", policy)
+	}
+}
+
+// Tests access levels are reported from policy JSON.
+func TestGetPoliciesFromJSON(t *testing.T) {
+	policyJSON, err := NewBuilder("mybucket").ReadOnly("public/").WriteOnly("uploads/").JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed BucketAccessPolicy
+	if err = json.Unmarshal(policyJSON, &parsed); err != nil || parsed.Version != "2012-10-17" {
+		t.Fatalf("Unexpected policy %s: %v", policyJSON, err)
+	}
+
+	policies, err := GetPoliciesFromJSON(policyJSON, "mybucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]BucketPolicy{
+		"mybucket/public/*":  BucketPolicyReadOnly,
+		"mybucket/uploads/*": BucketPolicyWriteOnly,
+	}
+	if len(policies) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, policies)
+	}
+	for prefix, policy := range expected {
+		if policies[prefix] != policy {
+			t.Errorf("Expected %s on %s, got %s", policy, prefix, policies[prefix])
+		}
+	}
+
+	if _, err = GetPoliciesFromJSON([]byte("{"), "mybucket"); err == nil {
+		t.Error("Expected malformed policy to fail")
+	}
+}
@@ -63,12 +63,18 @@ func encodeURL2Path(u *url.URL) (path string) {
 // PreSignV2 - presign the request in following style.
 // https://${S3_BUCKET}.s3.amazonaws.com/${S3_OBJECT}?AWSAccessKeyId=${S3_ACCESS_KEY}&Expires=${TIMESTAMP}&Signature=${SIGNATURE}.
 func PreSignV2(req http.Request, accessKeyID, secretAccessKey string, expires int64) *http.Request {
+	return PreSignV2At(req, accessKeyID, secretAccessKey, expires, time.Now().UTC())
+}
+
+// PreSignV2At is PreSignV2 with d as the signing time, the request
+// expires expires seconds after d.
+func PreSignV2At(req http.Request, accessKeyID, secretAccessKey string, expires int64, d time.Time) *http.Request {
 	// Presign is not needed for anonymous credentials.
 	if accessKeyID == "" || secretAccessKey == "" {
 		return &req
 	}
 
-	d := time.Now().UTC()
+	d = d.UTC()
 	// Find epoch expires when the request will expire.
 	epochExpires := d.Unix() + expires
 
@@ -133,13 +139,18 @@ func PostPresignSignatureV2(policyBase64, secretAccessKey string) string {
 
 // SignV2 sign the request before Do() (AWS Signature Version 2).
 func SignV2(req http.Request, accessKeyID, secretAccessKey string) *http.Request {
+	return SignV2At(req, accessKeyID, secretAccessKey, time.Now().UTC())
+}
+
+// SignV2At is SignV2 with d as the signing time.
+func SignV2At(req http.Request, accessKeyID, secretAccessKey string, d time.Time) *http.Request {
 	// Signature calculation is not needed for anonymous credentials.
 	if accessKeyID == "" || secretAccessKey == "" {
 		return &req
 	}
 
 	// Initial time.
-	d := time.Now().UTC()
+	d = d.UTC()
 
 	// Add date if not present.
 	if date := req.Header.Get("Date"); date == "" {
@@ -260,12 +271,21 @@ func writeCanonicalizedHeaders(buf *bytes.Buffer, req http.Request) {
 var resourceList = []string{
 	"acl",
 	"delete",
+	"encryption",
+	"legal-hold",
 	"location",
 	"logging",
 	"notification",
 	"partNumber",
 	"policy",
 	"requestPayment",
+	"response-cache-control",
+	"response-content-disposition",
+	"response-content-encoding",
+	"response-content-language",
+	"response-content-type",
+	"response-expires",
+	"retention",
 	"torrent",
 	"uploadId",
 	"uploads",
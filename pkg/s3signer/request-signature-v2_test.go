@@ -17,6 +17,8 @@
 package s3signer
 
 import (
+	"bytes"
+	"net/http"
 	"sort"
 	"testing"
 )
@@ -33,3 +35,17 @@ func TestResourceListSorting(t *testing.T) {
 		}
 	}
 }
+
+// Tests response header overrides are signed as sub-resources.
+func TestCanonicalizedResourceResponseOverrides(t *testing.T) {
+	req, err := http.NewRequest("GET", "http://localhost:9000/bucket/object?response-content-type=text/plain&response-content-disposition=attachment&unknown=value", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	writeCanonicalizedResource(buf, *req, false)
+	expected := "/bucket/object?response-content-disposition=attachment&response-content-type=text/plain"
+	if buf.String() != expected {
+		t.Fatalf("Expected %s, got %s", expected, buf.String())
+	}
+}
@@ -207,13 +207,19 @@ func getStringToSignV4(t time.Time, location, canonicalRequest string) string {
 // PreSignV4 presign the request, in accordance with
 // http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html.
 func PreSignV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, location string, expires int64) *http.Request {
+	return PreSignV4At(req, accessKeyID, secretAccessKey, sessionToken, location, expires, time.Now().UTC())
+}
+
+// PreSignV4At is PreSignV4 with t as the signing time, the request
+// is valid from t for expires seconds.
+func PreSignV4At(req http.Request, accessKeyID, secretAccessKey, sessionToken, location string, expires int64, t time.Time) *http.Request {
 	// Presign is not needed for anonymous credentials.
 	if accessKeyID == "" || secretAccessKey == "" {
 		return &req
 	}
 
 	// Initial time.
-	t := time.Now().UTC()
+	t = t.UTC()
 
 	// Get credential string.
 	credential := GetCredential(accessKeyID, location, t)
@@ -265,13 +271,18 @@ func PostPresignSignatureV4(policyBase64 string, t time.Time, secretAccessKey, l
 // SignV4 sign the request before Do(), in accordance with
 // http://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html.
 func SignV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, location string) *http.Request {
+	return SignV4At(req, accessKeyID, secretAccessKey, sessionToken, location, time.Now().UTC())
+}
+
+// SignV4At is SignV4 with t as the signing time.
+func SignV4At(req http.Request, accessKeyID, secretAccessKey, sessionToken, location string, t time.Time) *http.Request {
 	// Signature calculation is not needed for anonymous credentials.
 	if accessKeyID == "" || secretAccessKey == "" {
 		return &req
 	}
 
 	// Initial time.
-	t := time.Now().UTC()
+	t = t.UTC()
 
 	// Set x-amz-date.
 	req.Header.Set("X-Amz-Date", t.Format(iso8601DateFormat))
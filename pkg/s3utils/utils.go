@@ -205,12 +205,17 @@ func EncodePath(pathName string) string {
 // We support '.' with bucket names but we fallback to using path
 // style requests instead for such buckets.
 var (
-	validBucketName       = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9\.\-]{1,61}[A-Za-z0-9]$`)
-	validBucketNameStrict = regexp.MustCompile(`^[a-z0-9][a-z0-9\.\-]{1,61}[a-z0-9]$`)
-	ipAddress             = regexp.MustCompile(`^(\d+\.){3}\d+$`)
+	validBucketNameChars = regexp.MustCompile(`^[A-Za-z0-9\.\-]+$`)
+	ipAddress            = regexp.MustCompile(`^(\d+\.){3}\d+$`)
 )
 
-// Common checker for both stricter and basic validation.
+// isAlphaNumeric - tells whether c is an ASCII letter or number.
+func isAlphaNumeric(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// Common checker for both stricter and basic validation, each rule
+// reports its own error so callers learn what to fix.
 func checkBucketNameCommon(bucketName string, strict bool) (err error) {
 	if strings.TrimSpace(bucketName) == "" {
 		return errors.New("Bucket name cannot be empty")
@@ -224,19 +229,25 @@ func checkBucketNameCommon(bucketName string, strict bool) (err error) {
 	if ipAddress.MatchString(bucketName) {
 		return errors.New("Bucket name cannot be an ip address")
 	}
-	if strings.Contains(bucketName, "..") {
+	if !validBucketNameChars.MatchString(bucketName) {
 		return errors.New("Bucket name contains invalid characters")
 	}
-	if strict {
-		if !validBucketNameStrict.MatchString(bucketName) {
-			err = errors.New("Bucket name contains invalid characters")
-		}
-		return err
+	if strings.Contains(bucketName, "..") {
+		return errors.New("Bucket name cannot contain consecutive periods")
 	}
-	if !validBucketName.MatchString(bucketName) {
-		err = errors.New("Bucket name contains invalid characters")
+	if !isAlphaNumeric(bucketName[0]) || !isAlphaNumeric(bucketName[len(bucketName)-1]) {
+		return errors.New("Bucket name must begin and end with a letter or number")
 	}
-	return err
+	if !strict {
+		return nil
+	}
+	if strings.ToLower(bucketName) != bucketName {
+		return errors.New("Bucket name cannot contain uppercase letters")
+	}
+	if strings.Contains(bucketName, ".-") || strings.Contains(bucketName, "-.") {
+		return errors.New("Bucket name cannot contain a period next to a hyphen")
+	}
+	return nil
 }
 
 // CheckValidBucketName - checks if we have a valid input bucket name.
@@ -256,7 +267,7 @@ func CheckValidBucketNameStrict(bucketName string) (err error) {
 //   - http://docs.aws.amazon.com/AmazonS3/latest/dev/UsingMetadata.html
 func CheckValidObjectNamePrefix(objectName string) error {
 	if len(objectName) > 1024 {
-		return errors.New("Object name cannot be greater than 1024 characters")
+		return errors.New("Object name cannot be greater than 1024 bytes")
 	}
 	if !utf8.ValidString(objectName) {
 		return errors.New("Object name with non UTF-8 strings are not supported")
@@ -19,6 +19,7 @@ package s3utils
 import (
 	"errors"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -294,12 +295,12 @@ func TestIsValidBucketName(t *testing.T) {
 		// Flag to indicate whether test should Pass.
 		shouldPass bool
 	}{
-		{".mybucket", errors.New("Bucket name contains invalid characters"), false},
+		{".mybucket", errors.New("Bucket name must begin and end with a letter or number"), false},
 		{"$mybucket", errors.New("Bucket name contains invalid characters"), false},
-		{"mybucket-", errors.New("Bucket name contains invalid characters"), false},
+		{"mybucket-", errors.New("Bucket name must begin and end with a letter or number"), false},
 		{"my", errors.New("Bucket name cannot be smaller than 3 characters"), false},
 		{"", errors.New("Bucket name cannot be empty"), false},
-		{"my..bucket", errors.New("Bucket name contains invalid characters"), false},
+		{"my..bucket", errors.New("Bucket name cannot contain consecutive periods"), false},
 		{"192.168.1.168", errors.New("Bucket name cannot be an ip address"), false},
 		{"my.bucket.com", nil, true},
 		{"my-bucket", nil, true},
@@ -336,14 +337,17 @@ func TestIsValidBucketNameStrict(t *testing.T) {
 		// Flag to indicate whether test should Pass.
 		shouldPass bool
 	}{
-		{".mybucket", errors.New("Bucket name contains invalid characters"), false},
+		{".mybucket", errors.New("Bucket name must begin and end with a letter or number"), false},
 		{"$mybucket", errors.New("Bucket name contains invalid characters"), false},
-		{"mybucket-", errors.New("Bucket name contains invalid characters"), false},
+		{"mybucket-", errors.New("Bucket name must begin and end with a letter or number"), false},
 		{"my", errors.New("Bucket name cannot be smaller than 3 characters"), false},
 		{"", errors.New("Bucket name cannot be empty"), false},
-		{"my..bucket", errors.New("Bucket name contains invalid characters"), false},
+		{"my..bucket", errors.New("Bucket name cannot contain consecutive periods"), false},
 		{"192.168.1.168", errors.New("Bucket name cannot be an ip address"), false},
-		{"Mybucket", errors.New("Bucket name contains invalid characters"), false},
+		{"Mybucket", errors.New("Bucket name cannot contain uppercase letters"), false},
+		{"my.-bucket", errors.New("Bucket name cannot contain a period next to a hyphen"), false},
+		{"my-.bucket", errors.New("Bucket name cannot contain a period next to a hyphen"), false},
+		{"my_bucket", errors.New("Bucket name contains invalid characters"), false},
 		{"my.bucket.com", nil, true},
 		{"my-bucket", nil, true},
 		{"123my-bucket", nil, true},
@@ -367,3 +371,39 @@ func TestIsValidBucketNameStrict(t *testing.T) {
 	}
 
 }
+
+// Tests validate the object name validator.
+func TestIsValidObjectName(t *testing.T) {
+	testCases := []struct {
+		// Input.
+		objectName string
+		// Expected result.
+		err error
+		// Flag to indicate whether test should Pass.
+		shouldPass bool
+	}{
+		{"", errors.New("Object name cannot be empty"), false},
+		{"  ", errors.New("Object name cannot be empty"), false},
+		{strings.Repeat("a", 1025), errors.New("Object name cannot be greater than 1024 bytes"), false},
+		{strings.Repeat("é", 513), errors.New("Object name cannot be greater than 1024 bytes"), false},
+		{"object\xff", errors.New("Object name with non UTF-8 strings are not supported"), false},
+		{"photos/2017/january.jpg", nil, true},
+		{strings.Repeat("a", 1024), nil, true},
+	}
+
+	for i, testCase := range testCases {
+		err := CheckValidObjectName(testCase.objectName)
+		if err != nil && testCase.shouldPass {
+			t.Errorf("Test %d: Expected to pass, but failed with: <ERROR> %s", i+1, err.Error())
+		}
+		if err == nil && !testCase.shouldPass {
+			t.Errorf("Test %d: Expected to fail with <ERROR> \"%s\", but passed instead", i+1, testCase.err.Error())
+		}
+		// Failed as expected, but does it fail for the expected reason.
+		if err != nil && !testCase.shouldPass {
+			if err.Error() != testCase.err.Error() {
+				t.Errorf("Test %d: Expected to fail with error \"%s\", but instead failed with error \"%s\" instead", i+1, testCase.err.Error(), err.Error())
+			}
+		}
+	}
+}
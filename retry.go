@@ -17,6 +17,8 @@
 package minio
 
 import (
+	"io"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
@@ -41,47 +43,102 @@ const DefaultRetryUnit = time.Second
 // this maximum time duration.
 const DefaultRetryCap = time.Second * 30
 
-// newRetryTimer creates a timer with exponentially increasing
-// delays until the maximum retry attempts are reached.
-func (c Client) newRetryTimer(maxRetry int, unit time.Duration, cap time.Duration, jitter float64, doneCh chan struct{}) <-chan int {
-	attemptCh := make(chan int)
-
-	// computes the exponential backoff duration according to
-	// https://www.awsarchitectureblog.com/2015/03/backoff.html
-	exponentialBackoffWait := func(attempt int) time.Duration {
-		// normalize jitter to the range [0, 1.0]
-		if jitter < NoJitter {
-			jitter = NoJitter
-		}
-		if jitter > MaxJitter {
-			jitter = MaxJitter
-		}
+// RetryPolicy decides whether and when a failed request is retried.
+//
+// Requests whose body has been consumed and cannot be rewound are
+// never retried, regardless of the policy.
+type RetryPolicy interface {
+	// ShouldRetry reports whether the request should be sent again
+	// after attempt, starting at 1, failed. err is either the error
+	// which prevented a response, in which case resp is nil, or the
+	// error decoded from the unsuccessful resp. req is nil if the
+	// request could not be created, or if reading the body of a
+	// download failed with err and resuming it is considered.
+	ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) bool
+
+	// Backoff returns how long to wait before the attempt following
+	// attempt.
+	Backoff(attempt int) time.Duration
+}
 
-		//sleep = random_between(0, min(cap, base * 2 ** attempt))
-		sleep := unit * time.Duration(1<<uint(attempt))
-		if sleep > cap {
-			sleep = cap
-		}
-		if jitter != NoJitter {
-			sleep -= time.Duration(c.random.Float64() * float64(sleep) * jitter)
-		}
-		return sleep
+// DefaultRetryPolicy retries network errors, throttling and server
+// errors with exponentially increasing delays. POST requests, such as
+// completing multipart uploads, are not idempotent and are only
+// retried when no response was received or the server throttled them.
+type DefaultRetryPolicy struct {
+	// Maximum number of attempts, including the first one.
+	MaxRetry int
+	// Unit multiplied by two for each attempt, and the maximum
+	// delay between attempts.
+	Unit time.Duration
+	Cap  time.Duration
+	// Randomization of the delays, between NoJitter and MaxJitter.
+	Jitter float64
+
+	random *rand.Rand
+}
+
+// NewDefaultRetryPolicy - returns the retry policy used by clients
+// unless one is set with SetRetryPolicy.
+func NewDefaultRetryPolicy() *DefaultRetryPolicy {
+	return &DefaultRetryPolicy{
+		MaxRetry: MaxRetry,
+		Unit:     DefaultRetryUnit,
+		Cap:      DefaultRetryCap,
+		Jitter:   MaxJitter,
+		random:   rand.New(&lockedRandSource{src: rand.NewSource(time.Now().UTC().UnixNano())}),
 	}
+}
 
-	go func() {
-		defer close(attemptCh)
-		for i := 0; i < maxRetry; i++ {
-			select {
-			// Attempts start from 1.
-			case attemptCh <- i + 1:
-			case <-doneCh:
-				// Stop the routine.
-				return
-			}
-			time.Sleep(exponentialBackoffWait(i))
-		}
-	}()
-	return attemptCh
+// ShouldRetry implements RetryPolicy.
+func (p *DefaultRetryPolicy) ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) bool {
+	if attempt >= p.MaxRetry {
+		return false
+	}
+	if req != nil && req.Method == "POST" && resp != nil {
+		// The server may have acted on the request already, unless
+		// it was throttled.
+		return resp.StatusCode == 429 || resp.StatusCode == http.StatusServiceUnavailable
+	}
+	if err != nil && (isNetErrorRetryable(err) || isS3CodeRetryable(ToErrorResponse(err).Code)) {
+		return true
+	}
+	// Response body cut short, as seen when resuming downloads.
+	if err == io.ErrUnexpectedEOF {
+		return true
+	}
+	return resp != nil && isHTTPStatusRetryable(resp.StatusCode)
+}
+
+// Backoff implements RetryPolicy, computing the exponential backoff
+// duration according to
+// https://www.awsarchitectureblog.com/2015/03/backoff.html
+func (p *DefaultRetryPolicy) Backoff(attempt int) time.Duration {
+	// normalize jitter to the range [0, 1.0]
+	jitter := p.Jitter
+	if jitter < NoJitter {
+		jitter = NoJitter
+	}
+	if jitter > MaxJitter {
+		jitter = MaxJitter
+	}
+	// 1<<uint(attempt) below could overflow, so limit the value of attempt
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 30 {
+		attempt = 30
+	}
+
+	//sleep = random_between(0, min(cap, base * 2 ** attempt))
+	sleep := p.Unit * time.Duration(1<<uint(attempt-1))
+	if sleep > p.Cap {
+		sleep = p.Cap
+	}
+	if jitter != NoJitter && p.random != nil {
+		sleep -= time.Duration(p.random.Float64() * float64(sleep) * jitter)
+	}
+	return sleep
 }
 
 // isNetErrorRetryable - is network error retryable.
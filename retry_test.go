@@ -0,0 +1,150 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingRetryPolicy retries every failure without waiting.
+type countingRetryPolicy struct {
+	maxRetry int
+	statuses []int
+}
+
+func (p *countingRetryPolicy) ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) bool {
+	if resp != nil {
+		p.statuses = append(p.statuses, resp.StatusCode)
+	}
+	return attempt < p.maxRetry
+}
+
+func (p *countingRetryPolicy) Backoff(attempt int) time.Duration {
+	return 0
+}
+
+// Tests requests are retried according to the client retry policy.
+func TestRetryPolicy(t *testing.T) {
+	var requests, failures int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		requests++
+		ioutil.ReadAll(r.Body)
+		if requests <= failures {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	clnt, err := New(server.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	policy := &countingRetryPolicy{maxRetry: 3}
+	clnt.SetRetryPolicy(policy)
+
+	// Failures are retried, even those the default policy gives up on.
+	failures = 2
+	if _, err = clnt.StatObject("bucket", "object"); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if requests != 3 || len(policy.statuses) != 2 || policy.statuses[0] != http.StatusForbidden {
+		t.Fatalf("Expected 3 requests with 2 failures, got %d requests and %v", requests, policy.statuses)
+	}
+
+	// Attempts are limited by the policy.
+	requests, failures, policy.statuses = 0, 5, nil
+	if _, err = clnt.StatObject("bucket", "object"); ToErrorResponse(err).Code != "AccessDenied" {
+		t.Fatalf("Expected AccessDenied, got %v", err)
+	}
+	if requests != 3 {
+		t.Fatalf("Expected 3 requests, got %d", requests)
+	}
+
+	// Bodies which cannot be rewound are never sent twice.
+	requests, failures, policy.statuses = 0, 5, nil
+	body := io.LimitReader(strings.NewReader("data"), 4)
+	if _, err = (Core{clnt}).PutObject("bucket", "object", 4, body, nil, nil, nil); err == nil {
+		t.Fatal("Expected upload to fail")
+	}
+	if requests != 1 {
+		t.Fatalf("Expected a single request, got %d", requests)
+	}
+	// Bodies which can be rewound are.
+	requests, failures = 0, 2
+	if _, err = (Core{clnt}).PutObject("bucket", "object", 4, strings.NewReader("data"), nil, nil, nil); err != nil {
+		t.Fatal("Error:", err)
+	}
+	if requests != 3 {
+		t.Fatalf("Expected 3 requests, got %d", requests)
+	}
+}
+
+// Tests decisions and delays of the default retry policy.
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := NewDefaultRetryPolicy()
+	policy.Jitter = NoJitter
+	testCases := []struct {
+		attempt int
+		method  string
+		resp    *http.Response
+		err     error
+		retry   bool
+	}{
+		{1, "GET", &http.Response{StatusCode: http.StatusServiceUnavailable}, ErrorResponse{Code: "SlowDown"}, true},
+		{1, "GET", &http.Response{StatusCode: http.StatusForbidden}, ErrorResponse{Code: "AccessDenied"}, false},
+		{1, "GET", &http.Response{StatusCode: http.StatusBadRequest}, ErrorResponse{Code: "RequestTimeout"}, true},
+		{1, "", nil, ErrorResponse{Code: "InternalError"}, true},
+		{MaxRetry, "GET", &http.Response{StatusCode: http.StatusServiceUnavailable}, ErrorResponse{Code: "SlowDown"}, false},
+		// POST requests are retried only when throttled or without response.
+		{1, "POST", &http.Response{StatusCode: http.StatusInternalServerError}, ErrorResponse{Code: "InternalError"}, false},
+		{1, "POST", &http.Response{StatusCode: http.StatusServiceUnavailable}, ErrorResponse{Code: "SlowDown"}, true},
+		{1, "POST", nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+	}
+	for i, testCase := range testCases {
+		var req *http.Request
+		if testCase.method != "" {
+			req, _ = http.NewRequest(testCase.method, "http://localhost:9000/bucket/object", nil)
+		}
+		if retry := policy.ShouldRetry(testCase.attempt, req, testCase.resp, testCase.err); retry != testCase.retry {
+			t.Errorf("Test %d: expected retry %v, got %v", i+1, testCase.retry, retry)
+		}
+	}
+	for attempt, backoff := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		if got := policy.Backoff(attempt + 1); got != backoff {
+			t.Errorf("Expected backoff %v after attempt %d, got %v", backoff, attempt+1, got)
+		}
+	}
+	if got := policy.Backoff(10); got != DefaultRetryCap {
+		t.Errorf("Expected backoff to be capped at %v, got %v", DefaultRetryCap, got)
+	}
+}
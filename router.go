@@ -0,0 +1,332 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/pkg/s3utils"
+)
+
+// Router dispatches bucket and object operations to one of several
+// clients, for applications spanning multiple endpoints or regions.
+//
+// Buckets are routed by an explicit table first. Other buckets are
+// routed by their location, as reported by GetBucketLocation on the
+// default client, to the client registered for that location, or to
+// the default client otherwise. Buckets the default client cannot see
+// are looked up with the clients registered for locations.
+type Router struct {
+	mutex         *sync.RWMutex
+	defaultClient *Client
+	buckets       map[string]*Client
+	locations     map[string]*Client
+	// Buckets routed by location, cached after the first lookup.
+	resolved map[string]*Client
+}
+
+// Router implements API.
+var _ API = &Router{}
+
+// NewRouter - returns a router sending operations on buckets without
+// a route to defaultClient.
+func NewRouter(defaultClient *Client) *Router {
+	return &Router{
+		mutex:         &sync.RWMutex{},
+		defaultClient: defaultClient,
+		buckets:       make(map[string]*Client),
+		locations:     make(map[string]*Client),
+		resolved:      make(map[string]*Client),
+	}
+}
+
+// AddBucket - routes all operations on bucketName to clnt.
+func (r *Router) AddBucket(bucketName string, clnt *Client) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.buckets[bucketName] = clnt
+}
+
+// AddLocation - routes operations on buckets in location to clnt,
+// and creates buckets in location with clnt.
+func (r *Router) AddLocation(location string, clnt *Client) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.locations[location] = clnt
+	// Routes by location may have changed.
+	r.resolved = make(map[string]*Client)
+}
+
+// Route - returns the client operations on bucketName are sent to.
+func (r *Router) Route(bucketName string) (*Client, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	r.mutex.RLock()
+	clnt, ok := r.buckets[bucketName]
+	if !ok {
+		clnt, ok = r.resolved[bucketName]
+	}
+	hasLocations := len(r.locations) > 0
+	r.mutex.RUnlock()
+	if ok {
+		return clnt, nil
+	}
+	if !hasLocations {
+		return r.defaultClient, nil
+	}
+
+	location, err := r.bucketLocation(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	clnt, ok = r.locations[location]
+	if !ok {
+		// Not cached, the location may be registered later.
+		return r.defaultClient, nil
+	}
+	r.resolved[bucketName] = clnt
+	return clnt, nil
+}
+
+// bucketLocation - returns the location of bucketName, as reported by
+// the default client or else by the first client registered for a
+// location which has access to the bucket.
+func (r *Router) bucketLocation(bucketName string) (string, error) {
+	location, err := r.defaultClient.getBucketLocationStrict(bucketName)
+	if err == nil {
+		return location, nil
+	}
+	switch ToErrorResponse(err).Code {
+	case "NoSuchBucket", "AccessDenied":
+	default:
+		return "", err
+	}
+	for _, clnt := range r.clients() {
+		if clnt == r.defaultClient {
+			continue
+		}
+		if location, lErr := clnt.getBucketLocationStrict(bucketName); lErr == nil {
+			return location, nil
+		}
+	}
+	return "", err
+}
+
+// clients - returns all distinct clients of the router.
+func (r *Router) clients() []*Client {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	seen := map[*Client]bool{r.defaultClient: true}
+	clients := []*Client{r.defaultClient}
+	for _, routes := range []map[string]*Client{r.buckets, r.locations} {
+		for _, clnt := range routes {
+			if !seen[clnt] {
+				seen[clnt] = true
+				clients = append(clients, clnt)
+			}
+		}
+	}
+	return clients
+}
+
+// MakeBucket - creates bucketName with the client routed to by
+// bucketName, or registered for location.
+func (r *Router) MakeBucket(bucketName string, location string) error {
+	r.mutex.RLock()
+	clnt, ok := r.buckets[bucketName]
+	if !ok {
+		clnt, ok = r.locations[location]
+	}
+	r.mutex.RUnlock()
+	if !ok {
+		clnt = r.defaultClient
+	}
+	return clnt.MakeBucket(bucketName, location)
+}
+
+// ListBuckets - lists buckets of all clients. Buckets found through
+// several clients are only listed once.
+func (r *Router) ListBuckets() ([]BucketInfo, error) {
+	var buckets []BucketInfo
+	seen := make(map[string]bool)
+	for _, clnt := range r.clients() {
+		clntBuckets, err := clnt.ListBuckets()
+		if err != nil {
+			return nil, err
+		}
+		for _, bucket := range clntBuckets {
+			if !seen[bucket.Name] {
+				seen[bucket.Name] = true
+				buckets = append(buckets, bucket)
+			}
+		}
+	}
+	return buckets, nil
+}
+
+// BucketExists - verifies if bucketName exists with its client.
+func (r *Router) BucketExists(bucketName string) (bool, error) {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		// Looking up the location of a missing bucket fails.
+		if ToErrorResponse(err).Code == "NoSuchBucket" {
+			return false, nil
+		}
+		return false, err
+	}
+	return clnt.BucketExists(bucketName)
+}
+
+// RemoveBucket - removes bucketName with its client.
+func (r *Router) RemoveBucket(bucketName string) error {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return err
+	}
+	if err = clnt.RemoveBucket(bucketName); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	delete(r.resolved, bucketName)
+	r.mutex.Unlock()
+	return nil
+}
+
+// ListObjects - lists objects of bucketName with its client.
+func (r *Router) ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan ObjectInfo {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		objectStatCh := make(chan ObjectInfo, 1)
+		defer close(objectStatCh)
+		objectStatCh <- ObjectInfo{Err: err}
+		return objectStatCh
+	}
+	return clnt.ListObjects(bucketName, objectPrefix, recursive, doneCh)
+}
+
+// ListObjectsV2 - lists objects of bucketName with its client.
+func (r *Router) ListObjectsV2(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan ObjectInfo {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		objectStatCh := make(chan ObjectInfo, 1)
+		defer close(objectStatCh)
+		objectStatCh <- ObjectInfo{Err: err}
+		return objectStatCh
+	}
+	return clnt.ListObjectsV2(bucketName, objectPrefix, recursive, doneCh)
+}
+
+// GetObject - returns a reader of an object with its bucket's client.
+func (r *Router) GetObject(bucketName, objectName string) (*Object, error) {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	return clnt.GetObject(bucketName, objectName)
+}
+
+// FGetObject - downloads an object to filePath with its bucket's client.
+func (r *Router) FGetObject(bucketName, objectName, filePath string) error {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return err
+	}
+	return clnt.FGetObject(bucketName, objectName, filePath)
+}
+
+// PutObject - uploads an object with its bucket's client.
+func (r *Router) PutObject(bucketName, objectName string, reader io.Reader, contentType string) (n int64, err error) {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return 0, err
+	}
+	return clnt.PutObject(bucketName, objectName, reader, contentType)
+}
+
+// PutObjectWithMetadata - uploads an object with metadata with its
+// bucket's client.
+func (r *Router) PutObjectWithMetadata(bucketName, objectName string, reader io.Reader, metaData map[string][]string, progress io.Reader) (n int64, err error) {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return 0, err
+	}
+	return clnt.PutObjectWithMetadata(bucketName, objectName, reader, metaData, progress)
+}
+
+// FPutObject - uploads filePath as an object with its bucket's client.
+func (r *Router) FPutObject(bucketName, objectName, filePath, contentType string) (n int64, err error) {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return 0, err
+	}
+	return clnt.FPutObject(bucketName, objectName, filePath, contentType)
+}
+
+// StatObject - returns object metadata with its bucket's client.
+func (r *Router) StatObject(bucketName, objectName string) (ObjectInfo, error) {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return clnt.StatObject(bucketName, objectName)
+}
+
+// CopyObject - copies an object with the client of the destination
+// bucket. Source and destination buckets must be routed to the same
+// client.
+func (r *Router) CopyObject(bucketName string, objectName string, objectSource string, cpCond CopyConditions) error {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return err
+	}
+	srcBucket := strings.SplitN(strings.TrimPrefix(objectSource, "/"), "/", 2)[0]
+	srcClnt, err := r.Route(srcBucket)
+	if err != nil {
+		return err
+	}
+	if srcClnt != clnt {
+		return ErrInvalidArgument("Source bucket " + srcBucket + " and destination bucket " + bucketName + " are routed to different clients.")
+	}
+	return clnt.CopyObject(bucketName, objectName, objectSource, cpCond)
+}
+
+// RemoveObject - removes an object with its bucket's client.
+func (r *Router) RemoveObject(bucketName, objectName string) error {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		return err
+	}
+	return clnt.RemoveObject(bucketName, objectName)
+}
+
+// RemoveObjects - removes objects with their bucket's client.
+func (r *Router) RemoveObjects(bucketName string, objectsCh <-chan string) <-chan RemoveObjectError {
+	clnt, err := r.Route(bucketName)
+	if err != nil {
+		errorCh := make(chan RemoveObjectError, 1)
+		defer close(errorCh)
+		errorCh <- RemoveObjectError{Err: err}
+		return errorCh
+	}
+	return clnt.RemoveObjects(bucketName, objectsCh)
+}
@@ -0,0 +1,182 @@
+/*
+ * Minio Go Library for Amazon S3 Compatible Cloud Storage
+ * (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// routerTestServer answers location, list buckets and stat requests,
+// recording the buckets it served.
+type routerTestServer struct {
+	*httptest.Server
+	mutex   sync.Mutex
+	buckets []string
+}
+
+func newRouterTestServer(location func(bucketName string) string, buckets ...string) *routerTestServer {
+	s := &routerTestServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucketName := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0]
+		if _, ok := r.URL.Query()["location"]; ok {
+			// Buckets without location are not accessible, buckets
+			// located at "-" do not exist.
+			if location(bucketName) == "-" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist.</Message></Error>`))
+				return
+			}
+			if location(bucketName) == "" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`))
+				return
+			}
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` + location(bucketName) + `</LocationConstraint>`))
+			return
+		}
+		if bucketName == "" {
+			result := `<ListAllMyBucketsResult><Buckets>`
+			for _, bucket := range buckets {
+				result += `<Bucket><Name>` + bucket + `</Name><CreationDate>2017-06-01T10:00:00.000Z</CreationDate></Bucket>`
+			}
+			w.Write([]byte(result + `</Buckets></ListAllMyBucketsResult>`))
+			return
+		}
+		s.mutex.Lock()
+		s.buckets = append(s.buckets, bucketName)
+		s.mutex.Unlock()
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", "0")
+	}))
+	return s
+}
+
+// Tests operations are dispatched by bucket table and bucket location.
+func TestRouter(t *testing.T) {
+	location := func(bucketName string) string {
+		if strings.HasPrefix(bucketName, "eu-") {
+			return "eu-west-1"
+		}
+		return "us-east-1"
+	}
+	defaultLocation := func(bucketName string) string {
+		if bucketName == "eu-private-bucket" {
+			return ""
+		}
+		return location(bucketName)
+	}
+	defaultServer := newRouterTestServer(defaultLocation, "shared", "us-bucket")
+	defer defaultServer.Close()
+	euServer := newRouterTestServer(location, "shared", "eu-bucket")
+	defer euServer.Close()
+	tableServer := newRouterTestServer(location, "table-bucket")
+	defer tableServer.Close()
+
+	newClient := func(s *routerTestServer) *Client {
+		clnt, err := NewV4(s.Listener.Addr().String(), "access", "secret", false)
+		if err != nil {
+			t.Fatal("Error:", err)
+		}
+		return clnt
+	}
+	router := NewRouter(newClient(defaultServer))
+	euClnt := newClient(euServer)
+	router.AddLocation("eu-west-1", euClnt)
+	router.AddBucket("eu-table-bucket", newClient(tableServer))
+
+	for _, bucketName := range []string{"us-bucket", "eu-bucket", "eu-table-bucket", "eu-bucket", "eu-private-bucket"} {
+		if _, err := router.StatObject(bucketName, "object"); err != nil {
+			t.Fatal("Error:", err)
+		}
+	}
+	if strings.Join(defaultServer.buckets, ",") != "us-bucket" {
+		t.Errorf("Unexpected buckets served by default client %v", defaultServer.buckets)
+	}
+	if strings.Join(euServer.buckets, ",") != "eu-bucket,eu-bucket,eu-private-bucket" {
+		t.Errorf("Unexpected buckets served by location client %v", euServer.buckets)
+	}
+	if strings.Join(tableServer.buckets, ",") != "eu-table-bucket" {
+		t.Errorf("Unexpected buckets served by table client %v", tableServer.buckets)
+	}
+	if clnt, err := router.Route("eu-bucket"); err != nil || clnt != euClnt {
+		t.Errorf("Expected eu-bucket to be routed to the location client, got %v", err)
+	}
+	// Routes to the default client are not cached.
+	if _, ok := router.resolved["us-bucket"]; ok {
+		t.Error("Expected the route of us-bucket not to be cached")
+	}
+
+	buckets, err := router.ListBuckets()
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	var names []string
+	for _, bucket := range buckets {
+		names = append(names, bucket.Name)
+	}
+	if strings.Join(names, ",") != "shared,us-bucket,eu-bucket,table-bucket" && strings.Join(names, ",") != "shared,us-bucket,table-bucket,eu-bucket" {
+		t.Errorf("Unexpected buckets %v", names)
+	}
+
+	err = router.CopyObject("eu-bucket", "object", "us-bucket/object", NewCopyConditions())
+	if ToErrorResponse(err).Code != "InvalidArgument" {
+		t.Errorf("Expected copy across clients to fail, got %v", err)
+	}
+	if _, err = router.StatObject("", "object"); err == nil {
+		t.Error("Expected invalid bucket name to fail")
+	}
+}
+
+// Tests missing buckets do not exist for the router, as for clients,
+// once locations are registered.
+func TestRouterBucketExistsMissing(t *testing.T) {
+	location := func(bucketName string) string {
+		if bucketName == "missing-bucket" {
+			return "-"
+		}
+		return "us-east-1"
+	}
+	defaultServer := newRouterTestServer(location)
+	defer defaultServer.Close()
+	euServer := newRouterTestServer(location)
+	defer euServer.Close()
+
+	defaultClnt, err := NewV4(defaultServer.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	euClnt, err := NewV4(euServer.Listener.Addr().String(), "access", "secret", false)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	router := NewRouter(defaultClnt)
+	router.AddLocation("eu-west-1", euClnt)
+
+	if exists, err := router.BucketExists("missing-bucket"); err != nil || exists {
+		t.Fatalf("Expected missing-bucket not to exist, got %v, %v", exists, err)
+	}
+	if exists, err := router.BucketExists("us-bucket"); err != nil || !exists {
+		t.Fatalf("Expected us-bucket to exist, got %v, %v", exists, err)
+	}
+}
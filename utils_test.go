@@ -259,12 +259,12 @@ func TestIsValidBucketName(t *testing.T) {
 		// Flag to indicate whether test should Pass.
 		shouldPass bool
 	}{
-		{".mybucket", ErrInvalidBucketName("Bucket name contains invalid characters"), false},
-		{"mybucket.", ErrInvalidBucketName("Bucket name contains invalid characters"), false},
-		{"mybucket-", ErrInvalidBucketName("Bucket name contains invalid characters"), false},
+		{".mybucket", ErrInvalidBucketName("Bucket name must begin and end with a letter or number"), false},
+		{"mybucket.", ErrInvalidBucketName("Bucket name must begin and end with a letter or number"), false},
+		{"mybucket-", ErrInvalidBucketName("Bucket name must begin and end with a letter or number"), false},
 		{"my", ErrInvalidBucketName("Bucket name cannot be smaller than 3 characters"), false},
 		{"", ErrInvalidBucketName("Bucket name cannot be empty"), false},
-		{"my..bucket", ErrInvalidBucketName("Bucket name contains invalid characters"), false},
+		{"my..bucket", ErrInvalidBucketName("Bucket name cannot contain consecutive periods"), false},
 		{"my.bucket.com", nil, true},
 		{"my-bucket", nil, true},
 		{"123my-bucket", nil, true},